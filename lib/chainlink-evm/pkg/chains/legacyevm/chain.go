@@ -45,6 +45,7 @@ type Chain interface {
 	Config() config.ChainScopedConfig
 	LogBroadcaster() log.Broadcaster
 	HeadBroadcaster() heads.Broadcaster
+	ReorgBroadcaster() heads.ReorgBroadcaster
 	TxManager() txmgr.TxManager
 	HeadTracker() heads.Tracker
 	Logger() logger.Logger
@@ -101,17 +102,18 @@ func (c *LegacyChains) Get(id string) (types.ChainService, error) {
 
 type chain struct {
 	services.StateMachine
-	id              *big.Int
-	cfg             *config.ChainScoped
-	client          client.Client
-	txm             txmgr.TxManager
-	logger          logger.Logger
-	headBroadcaster heads.Broadcaster
-	headTracker     heads.Tracker
-	logBroadcaster  log.Broadcaster
-	logPoller       logpoller.LogPoller
-	balanceMonitor  monitor.BalanceMonitor
-	gasEstimator    gas.EvmFeeEstimator
+	id               *big.Int
+	cfg              *config.ChainScoped
+	client           client.Client
+	txm              txmgr.TxManager
+	logger           logger.Logger
+	headBroadcaster  heads.Broadcaster
+	reorgBroadcaster heads.ReorgBroadcaster
+	headTracker      heads.Tracker
+	logBroadcaster   log.Broadcaster
+	logPoller        logpoller.LogPoller
+	balanceMonitor   monitor.BalanceMonitor
+	gasEstimator     gas.EvmFeeEstimator
 
 	// Extends with support for the Tron TXM
 	tronTxm *trontxm.TronTxm
@@ -213,6 +215,7 @@ func newChain(cfg *config.ChainScoped, nodes []*toml.Node, opts ChainRelayOpts,
 	}
 
 	headBroadcaster := heads.NewBroadcaster(l)
+	reorgBroadcaster := heads.NewReorgBroadcaster(l, chainID, headBroadcaster)
 	headSaver := heads.NullSaver
 	var headTracker heads.Tracker
 	if !opts.ChainConfigs.RPCEnabled() {
@@ -236,15 +239,19 @@ func newChain(cfg *config.ChainScoped, nodes []*toml.Node, opts ChainRelayOpts,
 			logPoller = opts.GenLogPoller(chainID)
 		} else {
 			lpOpts := logpoller.Opts{
-				PollPeriod:               cfg.EVM().LogPollInterval(),
-				UseFinalityTag:           cfg.EVM().FinalityTagEnabled(),
-				FinalityDepth:            int64(cfg.EVM().FinalityDepth()),
-				BackfillBatchSize:        int64(cfg.EVM().LogBackfillBatchSize()),
-				RPCBatchSize:             int64(cfg.EVM().RPCDefaultBatchSize()),
-				KeepFinalizedBlocksDepth: int64(cfg.EVM().LogKeepBlocksDepth()),
-				LogPrunePageSize:         int64(cfg.EVM().LogPrunePageSize()),
-				BackupPollerBlockDelay:   int64(cfg.EVM().BackupLogPollerBlockDelay()),
-				ClientErrors:             cfg.EVM().NodePool().Errors(),
+				PollPeriod:                   cfg.EVM().LogPollInterval(),
+				UseFinalityTag:               cfg.EVM().FinalityTagEnabled(),
+				FinalityDepth:                int64(cfg.EVM().FinalityDepth()),
+				BackfillBatchSize:            int64(cfg.EVM().LogBackfillBatchSize()),
+				RPCBatchSize:                 int64(cfg.EVM().RPCDefaultBatchSize()),
+				KeepFinalizedBlocksDepth:     int64(cfg.EVM().LogKeepBlocksDepth()),
+				LogPrunePageSize:             int64(cfg.EVM().LogPrunePageSize()),
+				BackupPollerBlockDelay:       int64(cfg.EVM().BackupLogPollerBlockDelay()),
+				AutoRecoverFinalityViolation: cfg.EVM().LogAutoRecoverFinalityViolation(),
+				DisableBloomFilter:           !cfg.EVM().LogBloomFilterEnabled(),
+				ReorgAuditRetention:          cfg.EVM().LogReorgAuditRetention(),
+				FilterStatsInterval:          cfg.EVM().LogFilterStatsInterval(),
+				ClientErrors:                 cfg.EVM().NodePool().Errors(),
 			}
 
 			lpORM, err := logpoller.NewObservedORM(chainID, opts.DS, l)
@@ -293,7 +300,14 @@ func newChain(cfg *config.ChainScoped, nodes []*toml.Node, opts ChainRelayOpts,
 		logBroadcaster = &log.NullBroadcaster{ErrMsg: fmt.Sprintf("LogBroadcaster disabled for chain %d", chainID)}
 	} else if opts.GenLogBroadcaster == nil {
 		logORM := log.NewORM(opts.DS, *chainID)
-		logBroadcaster = log.NewBroadcaster(logORM, cl, cfg.EVM(), l, headSaver.LatestHeadFromDB, opts.MailMon)
+		if cfg.EVM().LogBroadcasterBackend() == toml.LogBroadcasterBackendLogPoller {
+			if logPoller == logpoller.LogPollerDisabled {
+				return nil, fmt.Errorf("LogBroadcasterBackend %q requires Feature.LogPoller for chain with ID %s", toml.LogBroadcasterBackendLogPoller, chainID)
+			}
+			logBroadcaster = log.NewLogPollerBroadcaster(logORM, logPoller, cfg.EVM(), l, *chainID)
+		} else {
+			logBroadcaster = log.NewBroadcaster(logORM, cl, cfg.EVM(), l, headSaver.LatestHeadFromDB, opts.MailMon)
+		}
 	} else {
 		logBroadcaster = opts.GenLogBroadcaster(chainID)
 	}
@@ -315,17 +329,18 @@ func newChain(cfg *config.ChainScoped, nodes []*toml.Node, opts ChainRelayOpts,
 	}
 
 	return &chain{
-		id:              chainID,
-		cfg:             cfg,
-		client:          cl,
-		txm:             txm,
-		logger:          l,
-		headBroadcaster: headBroadcaster,
-		headTracker:     headTracker,
-		logBroadcaster:  logBroadcaster,
-		logPoller:       logPoller,
-		balanceMonitor:  balanceMonitor,
-		gasEstimator:    gasEstimator,
+		id:               chainID,
+		cfg:              cfg,
+		client:           cl,
+		txm:              txm,
+		logger:           l,
+		headBroadcaster:  headBroadcaster,
+		reorgBroadcaster: reorgBroadcaster,
+		headTracker:      headTracker,
+		logBroadcaster:   logBroadcaster,
+		logPoller:        logPoller,
+		balanceMonitor:   balanceMonitor,
+		gasEstimator:     gasEstimator,
 
 		// Extends with support for the Tron TXM
 		tronTxm: tronTxm,
@@ -347,7 +362,7 @@ func (c *chain) Start(ctx context.Context) error {
 		// We do not start the log poller here, it gets
 		// started after the jobs so they have a chance to apply their filters.
 		var ms services.MultiStart
-		if err := ms.Start(ctx, c.txm, c.headBroadcaster, c.headTracker, c.logBroadcaster); err != nil {
+		if err := ms.Start(ctx, c.txm, c.headBroadcaster, c.reorgBroadcaster, c.headTracker, c.logBroadcaster); err != nil {
 			return err
 		}
 
@@ -378,6 +393,8 @@ func (c *chain) Close() error {
 		merr = multierr.Combine(merr, c.logBroadcaster.Close())
 		c.logger.Debug("Chain: stopping headTracker")
 		merr = multierr.Combine(merr, c.headTracker.Close())
+		c.logger.Debug("Chain: stopping reorgBroadcaster")
+		merr = multierr.Combine(merr, c.reorgBroadcaster.Close())
 		c.logger.Debug("Chain: stopping headBroadcaster")
 		merr = multierr.Combine(merr, c.headBroadcaster.Close())
 		c.logger.Debug("Chain: stopping evmTxm")
@@ -401,6 +418,7 @@ func (c *chain) Ready() (merr error) {
 		c.StateMachine.Ready(),
 		c.txm.Ready(),
 		c.headBroadcaster.Ready(),
+		c.reorgBroadcaster.Ready(),
 		c.headTracker.Ready(),
 		c.logBroadcaster.Ready(),
 	)
@@ -418,6 +436,7 @@ func (c *chain) HealthReport() map[string]error {
 	report := map[string]error{c.Name(): c.Healthy()}
 	services.CopyHealth(report, c.txm.HealthReport())
 	services.CopyHealth(report, c.headBroadcaster.HealthReport())
+	services.CopyHealth(report, c.reorgBroadcaster.HealthReport())
 	services.CopyHealth(report, c.headTracker.HealthReport())
 	services.CopyHealth(report, c.logBroadcaster.HealthReport())
 
@@ -539,17 +558,18 @@ func (c *chain) ListNodeStatuses(ctx context.Context, pageSize int32, pageToken
 	return common.ListNodeStatuses(int(pageSize), pageToken, c.listNodeStatuses)
 }
 
-func (c *chain) ID() *big.Int                           { return c.id }
-func (c *chain) Client() client.Client                  { return c.client }
-func (c *chain) Config() config.ChainScopedConfig       { return c.cfg }
-func (c *chain) LogBroadcaster() log.Broadcaster        { return c.logBroadcaster }
-func (c *chain) LogPoller() logpoller.LogPoller         { return c.logPoller }
-func (c *chain) HeadBroadcaster() heads.Broadcaster     { return c.headBroadcaster }
-func (c *chain) TxManager() txmgr.TxManager             { return c.txm }
-func (c *chain) HeadTracker() heads.Tracker             { return c.headTracker }
-func (c *chain) Logger() logger.Logger                  { return c.logger }
-func (c *chain) BalanceMonitor() monitor.BalanceMonitor { return c.balanceMonitor }
-func (c *chain) GasEstimator() gas.EvmFeeEstimator      { return c.gasEstimator }
+func (c *chain) ID() *big.Int                             { return c.id }
+func (c *chain) Client() client.Client                    { return c.client }
+func (c *chain) Config() config.ChainScopedConfig         { return c.cfg }
+func (c *chain) LogBroadcaster() log.Broadcaster          { return c.logBroadcaster }
+func (c *chain) LogPoller() logpoller.LogPoller           { return c.logPoller }
+func (c *chain) HeadBroadcaster() heads.Broadcaster       { return c.headBroadcaster }
+func (c *chain) ReorgBroadcaster() heads.ReorgBroadcaster { return c.reorgBroadcaster }
+func (c *chain) TxManager() txmgr.TxManager               { return c.txm }
+func (c *chain) HeadTracker() heads.Tracker               { return c.headTracker }
+func (c *chain) Logger() logger.Logger                    { return c.logger }
+func (c *chain) BalanceMonitor() monitor.BalanceMonitor   { return c.balanceMonitor }
+func (c *chain) GasEstimator() gas.EvmFeeEstimator        { return c.gasEstimator }
 
 // Add ChainTronSupport
 func (c *chain) GetTronTXM() *trontxm.TronTxm { return c.tronTxm }
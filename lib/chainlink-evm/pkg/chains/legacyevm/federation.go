@@ -0,0 +1,160 @@
+package legacyevm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+)
+
+// ErrUnexpectedFederatedCursor is returned for cursors which weren't created by FederatedLogs.FilteredLogs.
+var ErrUnexpectedFederatedCursor = errors.New("unexpected federated cursor format")
+
+// FederatedLogs runs LogPoller queries over several chains of a LegacyChainContainer, for services watching the same
+// contracts on multiple chains.
+type FederatedLogs struct {
+	chains LegacyChainContainer
+}
+
+func NewFederatedLogs(chains LegacyChainContainer) *FederatedLogs {
+	return &FederatedLogs{chains: chains}
+}
+
+// FilteredLogs runs filter on the LogPoller of each chain in chainIDs concurrently and returns up to count logs, merged in
+// ascending block timestamp order. Ties are broken by numeric chain ID, then block number and log index. Logs are tagged with
+// their chain through Log.EVMChainID.
+//
+// cursor is empty for the first page. For the following pages, pass the returned cursor, which holds the position
+// reached on each chain. Just like single chain cursors, pages after the first one are limited to finalized logs,
+// so filter has to include a finalized confidence level.
+func (f *FederatedLogs) FilteredLogs(ctx context.Context, chainIDs []string, filter []query.Expression, count uint64, cursor string, queryName string) ([]logpoller.Log, string, error) {
+	if count == 0 {
+		return nil, "", errors.New("count must be greater than 0")
+	}
+
+	cursors, err := parseFederatedCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pollers := make([]logpoller.LogPoller, len(chainIDs))
+	numericIDs := make([]*big.Int, len(chainIDs))
+	for i, id := range chainIDs {
+		n, ok := new(big.Int).SetString(id, 10)
+		if !ok {
+			return nil, "", fmt.Errorf("invalid chain ID %q", id)
+		}
+		numericIDs[i] = n
+		c, err2 := f.chains.Get(id)
+		if err2 != nil {
+			return nil, "", err2
+		}
+		chain, ok := c.(Chain)
+		if !ok {
+			return nil, "", fmt.Errorf("chain %s does not support LogPoller queries in LOOPP mode", id)
+		}
+		pollers[i] = chain.LogPoller()
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]logpoller.Log, len(chainIDs))
+		errs    = make([]error, len(chainIDs))
+	)
+	for i := range chainIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			limit := query.NewLimitAndSort(query.CountLimit(count), query.NewSortBySequence(query.Asc))
+			if c, ok := cursors[chainIDs[i]]; ok {
+				limit = query.NewLimitAndSort(query.CursorLimit(c, query.CursorFollowing, count))
+			}
+			results[i], errs[i] = pollers[i].FilteredLogs(ctx, filter, limit, queryName)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("chain %s: %w", chainIDs[i], errs[i])
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err = errors.Join(errs...); err != nil {
+		return nil, "", err
+	}
+
+	var merged []federatedLog
+	for i, logs := range results {
+		for _, l := range logs {
+			merged = append(merged, federatedLog{chainID: chainIDs[i], numericID: numericIDs[i], log: l})
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if !a.log.BlockTimestamp.Equal(b.log.BlockTimestamp) {
+			return a.log.BlockTimestamp.Before(b.log.BlockTimestamp)
+		}
+		if c := a.numericID.Cmp(b.numericID); c != 0 {
+			return c < 0
+		}
+		if a.log.BlockNumber != b.log.BlockNumber {
+			return a.log.BlockNumber < b.log.BlockNumber
+		}
+		return a.log.LogIndex < b.log.LogIndex
+	})
+	if uint64(len(merged)) > count {
+		merged = merged[:count]
+	}
+
+	logs := make([]logpoller.Log, 0, len(merged))
+	for _, m := range merged {
+		cursors[m.chainID] = logpoller.FormatContractReaderCursor(m.log)
+		logs = append(logs, m.log)
+	}
+	next, err := formatFederatedCursor(cursors)
+	if err != nil {
+		return nil, "", err
+	}
+	return logs, next, nil
+}
+
+type federatedLog struct {
+	chainID   string
+	numericID *big.Int
+	log       logpoller.Log
+}
+
+// formatFederatedCursor encodes the single chain cursors by chain ID as base64 JSON, so that neither chain IDs nor
+// cursors have to avoid any separator.
+func formatFederatedCursor(cursors map[string]string) (string, error) {
+	b, err := json.Marshal(cursors)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode federated cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func parseFederatedCursor(cursor string) (map[string]string, error) {
+	cursors := make(map[string]string)
+	if cursor == "" {
+		return cursors, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnexpectedFederatedCursor, err)
+	}
+	if err = json.Unmarshal(b, &cursors); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnexpectedFederatedCursor, err)
+	}
+	for id, c := range cursors {
+		if id == "" || c == "" {
+			return nil, fmt.Errorf("%w: empty chain ID or cursor in %q", ErrUnexpectedFederatedCursor, b)
+		}
+	}
+	return cursors, nil
+}
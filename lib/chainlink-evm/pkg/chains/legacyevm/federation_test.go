@@ -0,0 +1,104 @@
+package legacyevm_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-evm/pkg/chains/legacyevm"
+	"github.com/smartcontractkit/chainlink-evm/pkg/chains/legacyevm/mocks"
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// sliceLogPoller serves FilteredLogs from logs sorted by block and log index, ignoring the filter expressions.
+type sliceLogPoller struct {
+	logpoller.LogPoller
+	logs []logpoller.Log
+}
+
+func (s *sliceLogPoller) FilteredLogs(_ context.Context, _ []query.Expression, limit query.LimitAndSort, _ string) ([]logpoller.Log, error) {
+	start := 0
+	if limit.HasCursorLimit() {
+		for i, l := range s.logs {
+			if logpoller.FormatContractReaderCursor(l) == limit.Limit.Cursor {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+int(limit.Limit.Count), len(s.logs)) //nolint:gosec // G115
+	return s.logs[start:end], nil
+}
+
+func TestFederatedLogs_FilteredLogs(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	newLogs := func(chainID int64, offsets ...int) []logpoller.Log {
+		var logs []logpoller.Log
+		for i, offset := range offsets {
+			logs = append(logs, logpoller.Log{
+				EVMChainID:     ubig.NewI(chainID),
+				BlockNumber:    int64(i + 1),
+				BlockTimestamp: base.Add(time.Duration(offset) * time.Second),
+			})
+		}
+		return logs
+	}
+
+	m := map[string]types.ChainService{}
+	for id, lp := range map[int64]*sliceLogPoller{
+		1: {logs: newLogs(1, 0, 10, 20)},
+		2: {logs: newLogs(2, 5, 15)},
+	} {
+		c := mocks.NewChain(t)
+		c.On("LogPoller").Return(lp)
+		m[big.NewInt(id).String()] = c
+	}
+	f := legacyevm.NewFederatedLogs(legacyevm.NewLegacyChains(m))
+	ctx := context.Background()
+
+	var got []int64
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		logs, next, err := f.FilteredLogs(ctx, []string{"1", "2"}, nil, 2, cursor, "")
+		require.NoError(t, err)
+		for _, l := range logs {
+			got = append(got, l.BlockTimestamp.Unix()-base.Unix())
+		}
+		cursor = next
+	}
+	assert.Equal(t, []int64{0, 5, 10, 15, 20}, got)
+
+	_, _, err := f.FilteredLogs(ctx, []string{"1"}, nil, 2, "garbage", "")
+	require.ErrorIs(t, err, legacyevm.ErrUnexpectedFederatedCursor)
+
+	_, _, err = f.FilteredLogs(ctx, []string{"3"}, nil, 2, "", "")
+	require.Error(t, err)
+}
+
+func TestFederatedLogs_FilteredLogs_TieBreak(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	m := map[string]types.ChainService{}
+	for _, id := range []int64{2, 10} {
+		c := mocks.NewChain(t)
+		c.On("LogPoller").Return(&sliceLogPoller{logs: []logpoller.Log{{EVMChainID: ubig.NewI(id), BlockNumber: 1, BlockTimestamp: ts}}})
+		m[big.NewInt(id).String()] = c
+	}
+	f := legacyevm.NewFederatedLogs(legacyevm.NewLegacyChains(m))
+
+	// chain 2 comes before chain 10, although "10" < "2"
+	logs, cursor, err := f.FilteredLogs(context.Background(), []string{"10", "2"}, nil, 1, "", "")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, int64(2), logs[0].EVMChainID.Int64())
+
+	logs, _, err = f.FilteredLogs(context.Background(), []string{"10", "2"}, nil, 1, cursor, "")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, int64(10), logs[0].EVMChainID.Int64())
+}
@@ -16,6 +16,8 @@ import (
 
 	heads "github.com/smartcontractkit/chainlink-framework/chains/heads"
 
+	pkgheads "github.com/smartcontractkit/chainlink-evm/pkg/heads"
+
 	log "github.com/smartcontractkit/chainlink-evm/pkg/log"
 
 	logger "github.com/smartcontractkit/chainlink-common/pkg/logger"
@@ -940,6 +942,51 @@ func (_c *Chain_Ready_Call) RunAndReturn(run func() error) *Chain_Ready_Call {
 	return _c
 }
 
+// ReorgBroadcaster provides a mock function with no fields
+func (_m *Chain) ReorgBroadcaster() pkgheads.ReorgBroadcaster {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorgBroadcaster")
+	}
+
+	var r0 pkgheads.ReorgBroadcaster
+	if rf, ok := ret.Get(0).(func() pkgheads.ReorgBroadcaster); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(pkgheads.ReorgBroadcaster)
+	}
+
+	return r0
+}
+
+// Chain_ReorgBroadcaster_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorgBroadcaster'
+type Chain_ReorgBroadcaster_Call struct {
+	*mock.Call
+}
+
+// ReorgBroadcaster is a helper method to define mock.On call
+func (_e *Chain_Expecter) ReorgBroadcaster() *Chain_ReorgBroadcaster_Call {
+	return &Chain_ReorgBroadcaster_Call{Call: _e.mock.On("ReorgBroadcaster")}
+}
+
+func (_c *Chain_ReorgBroadcaster_Call) Run(run func()) *Chain_ReorgBroadcaster_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Chain_ReorgBroadcaster_Call) Return(_a0 pkgheads.ReorgBroadcaster) *Chain_ReorgBroadcaster_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Chain_ReorgBroadcaster_Call) RunAndReturn(run func() pkgheads.ReorgBroadcaster) *Chain_ReorgBroadcaster_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Replay provides a mock function with given fields: ctx, fromBlock, args
 func (_m *Chain) Replay(ctx context.Context, fromBlock string, args map[string]interface{}) error {
 	ret := _m.Called(ctx, fromBlock, args)
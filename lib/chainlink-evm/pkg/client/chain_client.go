@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 
@@ -91,6 +92,14 @@ type Client interface {
 	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
 
+	// TraceTransaction returns the call trace of a mined transaction, including its internal calls.
+	TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error)
+	// TraceCall returns the call trace of msg, executed on top of the state of the given block.
+	// blockNumber can be specified as `nil` to imply latest block
+	TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error)
+	// TraceTransactionPrestate returns the state of the accounts touched by a mined transaction, before it was executed.
+	TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (PrestateTrace, error)
+
 	IsL2() bool
 
 	// Simulate the transaction prior to sending to catch zk out-of-counters errors ahead of time
@@ -106,6 +115,7 @@ type chainClient struct {
 	logger       logger.SugaredLogger
 	chainType    chaintype.ChainType
 	clientErrors evmconfig.ClientErrors
+	quorum       *quorumConfig // nil unless quorum reads are enabled
 }
 
 func NewChainClient(
@@ -120,6 +130,22 @@ func NewChainClient(
 	deathDeclarationDelay time.Duration,
 	chainType chaintype.ChainType,
 ) Client {
+	return newChainClient(lggr, metrics, selectionMode, leaseDuration, nodes, sendonlys, chainID, clientErrors,
+		deathDeclarationDelay, chainType)
+}
+
+func newChainClient(
+	lggr logger.Logger,
+	metrics metrics.GenericMultiNodeMetrics,
+	selectionMode string,
+	leaseDuration time.Duration,
+	nodes []multinode.Node[*big.Int, *RPCClient],
+	sendonlys []multinode.SendOnlyNode[*big.Int, *RPCClient],
+	chainID *big.Int,
+	clientErrors evmconfig.ClientErrors,
+	deathDeclarationDelay time.Duration,
+	chainType chaintype.ChainType,
+) *chainClient {
 	chainFamily := "EVM"
 	multiNode := multinode.NewMultiNode[*big.Int, *RPCClient](
 		lggr,
@@ -157,6 +183,15 @@ func (c *chainClient) BalanceAt(ctx context.Context, account common.Address, blo
 	if err != nil {
 		return nil, err
 	}
+	if c.quorum != nil {
+		pinned, err := r.pinBlock(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return quorumRead(ctx, c, r, "BalanceAt", func(ctx context.Context, rpc *RPCClient) (*big.Int, error) {
+			return rpc.BalanceAtHash(ctx, account, pinned.Hash)
+		}, (*big.Int).String)
+	}
 	return r.BalanceAt(ctx, account, blockNumber)
 }
 
@@ -251,6 +286,15 @@ func (c *chainClient) CallContract(ctx context.Context, msg ethereum.CallMsg, bl
 	if err != nil {
 		return nil, err
 	}
+	if c.quorum != nil {
+		pinned, err := r.pinBlock(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return quorumRead(ctx, c, r, "CallContract", func(ctx context.Context, rpc *RPCClient) ([]byte, error) {
+			return rpc.CallContractAtHash(ctx, msg, pinned.Hash)
+		}, hexutil.Encode)
+	}
 	return r.CallContract(ctx, msg, blockNumber)
 }
 
@@ -331,6 +375,15 @@ func (c *chainClient) HeadByNumber(ctx context.Context, n *big.Int) (*evmtypes.H
 	if err != nil {
 		return nil, err
 	}
+	if c.quorum != nil {
+		pinned, err := r.pinBlock(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		return quorumRead(ctx, c, r, "HeadByNumber", func(ctx context.Context, rpc *RPCClient) (*evmtypes.Head, error) {
+			return rpc.BlockByNumber(ctx, big.NewInt(pinned.Number))
+		}, func(head *evmtypes.Head) string { return head.Hash.Hex() })
+	}
 	return r.BlockByNumber(ctx, n)
 }
 
@@ -491,11 +544,36 @@ func (c *chainClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlo
 	return r.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
+func (c *chainClient) TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error) {
+	r, err := c.multiNode.SelectRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.TraceTransaction(ctx, txHash)
+}
+
+func (c *chainClient) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error) {
+	r, err := c.multiNode.SelectRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.TraceCall(ctx, msg, blockNumber)
+}
+
+func (c *chainClient) TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (PrestateTrace, error) {
+	r, err := c.multiNode.SelectRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.TraceTransactionPrestate(ctx, txHash)
+}
+
 func (c *chainClient) CheckTxValidity(ctx context.Context, from common.Address, to common.Address, data []byte) *SendError {
 	msg := ethereum.CallMsg{
 		From: from,
 		To:   &to,
 		Data: data,
 	}
-	return SimulateTransaction(ctx, c, c.logger, c.chainType, msg)
+	_, err := SimulateTransaction(ctx, c, c.logger, c.chainType, msg)
+	return err
 }
@@ -2046,6 +2046,184 @@ func (_c *Client_TokenBalance_Call) RunAndReturn(run func(context.Context, commo
 	return _c
 }
 
+// TraceCall provides a mock function with given fields: ctx, msg, blockNumber
+func (_m *Client) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*client.CallFrame, error) {
+	ret := _m.Called(ctx, msg, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TraceCall")
+	}
+
+	var r0 *client.CallFrame
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg, *big.Int) (*client.CallFrame, error)); ok {
+		return rf(ctx, msg, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg, *big.Int) *client.CallFrame); ok {
+		r0 = rf(ctx, msg, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.CallFrame)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg, *big.Int) error); ok {
+		r1 = rf(ctx, msg, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_TraceCall_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TraceCall'
+type Client_TraceCall_Call struct {
+	*mock.Call
+}
+
+// TraceCall is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg ethereum.CallMsg
+//   - blockNumber *big.Int
+func (_e *Client_Expecter) TraceCall(ctx interface{}, msg interface{}, blockNumber interface{}) *Client_TraceCall_Call {
+	return &Client_TraceCall_Call{Call: _e.mock.On("TraceCall", ctx, msg, blockNumber)}
+}
+
+func (_c *Client_TraceCall_Call) Run(run func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int)) *Client_TraceCall_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ethereum.CallMsg), args[2].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Client_TraceCall_Call) Return(_a0 *client.CallFrame, _a1 error) *Client_TraceCall_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_TraceCall_Call) RunAndReturn(run func(context.Context, ethereum.CallMsg, *big.Int) (*client.CallFrame, error)) *Client_TraceCall_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TraceTransaction provides a mock function with given fields: ctx, txHash
+func (_m *Client) TraceTransaction(ctx context.Context, txHash common.Hash) (*client.CallFrame, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TraceTransaction")
+	}
+
+	var r0 *client.CallFrame
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*client.CallFrame, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *client.CallFrame); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.CallFrame)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_TraceTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TraceTransaction'
+type Client_TraceTransaction_Call struct {
+	*mock.Call
+}
+
+// TraceTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txHash common.Hash
+func (_e *Client_Expecter) TraceTransaction(ctx interface{}, txHash interface{}) *Client_TraceTransaction_Call {
+	return &Client_TraceTransaction_Call{Call: _e.mock.On("TraceTransaction", ctx, txHash)}
+}
+
+func (_c *Client_TraceTransaction_Call) Run(run func(ctx context.Context, txHash common.Hash)) *Client_TraceTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Client_TraceTransaction_Call) Return(_a0 *client.CallFrame, _a1 error) *Client_TraceTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_TraceTransaction_Call) RunAndReturn(run func(context.Context, common.Hash) (*client.CallFrame, error)) *Client_TraceTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TraceTransactionPrestate provides a mock function with given fields: ctx, txHash
+func (_m *Client) TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (client.PrestateTrace, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TraceTransactionPrestate")
+	}
+
+	var r0 client.PrestateTrace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (client.PrestateTrace, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) client.PrestateTrace); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(client.PrestateTrace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_TraceTransactionPrestate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TraceTransactionPrestate'
+type Client_TraceTransactionPrestate_Call struct {
+	*mock.Call
+}
+
+// TraceTransactionPrestate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txHash common.Hash
+func (_e *Client_Expecter) TraceTransactionPrestate(ctx interface{}, txHash interface{}) *Client_TraceTransactionPrestate_Call {
+	return &Client_TraceTransactionPrestate_Call{Call: _e.mock.On("TraceTransactionPrestate", ctx, txHash)}
+}
+
+func (_c *Client_TraceTransactionPrestate_Call) Run(run func(ctx context.Context, txHash common.Hash)) *Client_TraceTransactionPrestate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Client_TraceTransactionPrestate_Call) Return(_a0 client.PrestateTrace, _a1 error) *Client_TraceTransactionPrestate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_TraceTransactionPrestate_Call) RunAndReturn(run func(context.Context, common.Hash) (client.PrestateTrace, error)) *Client_TraceTransactionPrestate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransactionByHash provides a mock function with given fields: ctx, txHash
 func (_m *Client) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, error) {
 	ret := _m.Called(ctx, txHash)
@@ -61,6 +61,9 @@ func NewClientConfigs(
 		DeathDeclarationDelay:      commonconfig.MustNewDuration(deathDeclarationDelay),
 		FinalizedBlockPollInterval: commonconfig.MustNewDuration(finalizedBlockPollInterval),
 		NewHeadsPollInterval:       commonconfig.MustNewDuration(newHeadsPollInterval),
+		ResponseCacheSize:          new(uint32),
+		QuorumNodes:                new(uint32),
+		QuorumThreshold:            new(uint32),
 	}
 	nodePoolCfg := &evmconfig.NodePoolConfig{C: nodePool}
 	chainConfig := &evmconfig.EVMConfig{
@@ -30,6 +30,14 @@ func (s *SendError) Error() string {
 	return s.err.Error()
 }
 
+// Revert returns the decoded revert data of the error, or nil if it has none or it does not match a registered error.
+func (s *SendError) Revert() *DecodedRevert {
+	if s == nil {
+		return nil
+	}
+	return DecodeRevert(s.err)
+}
+
 // Fatal indicates whether the error should be considered fatal or not
 // Fatal errors mean that no matter how many times the send is retried, no node
 // will ever accept it
@@ -530,9 +538,20 @@ func (err JsonError) ErrorData() interface{} {
 }
 
 func (err JsonError) String() string {
+	if revert := err.Revert(); revert != nil {
+		return fmt.Sprintf("json-rpc error { Code = %d, Message = '%s', Data = '%v', Revert = '%s' }", err.Code, err.Message, err.Data, revert)
+	}
 	return fmt.Sprintf("json-rpc error { Code = %d, Message = '%s', Data = '%v' }", err.Code, err.Message, err.Data)
 }
 
+// Revert returns the decoded revert data of the error, or nil if it has none or it does not match a registered error.
+func (err JsonError) Revert() *DecodedRevert {
+	if data, ok := revertData(err.Data); ok {
+		return RevertErrors.Decode(data)
+	}
+	return nil
+}
+
 func ExtractRPCErrorOrNil(err error) *JsonError {
 	jErr, eErr := ExtractRPCError(err)
 	if eErr != nil {
@@ -588,7 +607,11 @@ func ClassifySendError(err error, clientErrors config.ClientErrors, lggr logger.
 	configErrors := ClientErrorRegexes(clientErrors)
 
 	if sendError.Fatal(configErrors) {
-		lggr.Criticalw("Fatal error sending transaction", "err", sendError, "etx", tx)
+		if revert := sendError.Revert(); revert != nil {
+			lggr.Criticalw("Fatal error sending transaction", "err", sendError, "revert", revert, "etx", tx)
+		} else {
+			lggr.Criticalw("Fatal error sending transaction", "err", sendError, "etx", tx)
+		}
 		// Attempt is thrown away in this case; we don't need it since it never got accepted by a node
 		return multinode.Fatal
 	}
@@ -698,6 +721,14 @@ var hyperliquid = ClientErrors{
 	MissingBlocks:  regexp.MustCompile(`(: |^)invalid block range$`),
 }
 
+// Block range and result limits of eth_getLogs, which several providers and node implementations return with varying
+// error codes. Examples: Erigon and Bor "query returned more than 10000 results", BSC "exceed maximum block range: 5000",
+// Ankr and BlastAPI "block range is too wide", Base and Cloudflare "block range too large", LlamaRPC
+// "query exceeds max results 20000".
+var logsRangeLimit = ClientErrors{
+	TooManyResults: regexp.MustCompile(`(?i)(: |^)(query returned more than [0-9]+ results|exceed(s|ed)? maximum block range|block range (is )?too (large|wide)|query exceeds max results [0-9]+)`),
+}
+
 // Linkpool, Blockdaemon, and Chainstack all return "request timed out" if the log results are too large for them to process
 var defaultClient = ClientErrors{
 	TooManyResults: regexp.MustCompile(`request timed out|408 Request Timed Out$`),
@@ -724,6 +755,18 @@ const (
 	jsonRPCQuicknodeTooManyResults = -32614 // Undocumented error code used by Quicknode for too many results error
 )
 
+// isTimeout returns true if the request timed out, in the context or on the RPC.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var rpcErr rpc.Error
+	if !pkgerrors.As(err, &rpcErr) {
+		return false
+	}
+	return defaultClient.ErrIs(rpcErr, TooManyResults)
+}
+
 func IsTooManyResults(err error, clientErrors config.ClientErrors) bool {
 	// Context timeouts often occur when receiving too many results from RPCs
 	if errors.Is(err, context.DeadlineExceeded) {
@@ -767,7 +810,7 @@ func IsTooManyResults(err error, clientErrors config.ClientErrors) bool {
 			return true
 		}
 	}
-	return false
+	return logsRangeLimit.ErrIs(rpcErr, TooManyResults)
 }
 
 // IsMissingBlocks indicates that the error is caused by the rpc server not having some of the blocks requested at all
@@ -524,6 +524,26 @@ func Test_IsTooManyResultsError(t *testing.T) {
 		"code":-32000}`,
 			true,
 			"Drpc",
+		}, {`{
+		"code":-32005,
+		"message":"query returned more than 10000 results"}`,
+			true,
+			"Erigon",
+		}, {`{
+		"code":-32000,
+		"message":"exceed maximum block range: 5000"}`,
+			true,
+			"BSC",
+		}, {`{
+		"code":-32602,
+		"message":"block range is too wide"}`,
+			true,
+			"Ankr",
+		}, {`{
+		"code":-32614,
+		"message":"Block range too large"}`,
+			true,
+			"Cloudflare",
 		}, {`
 <!DOCTYPE html>
 <html>
@@ -27,16 +27,27 @@ func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, client
 		return nil, fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 
+	var cache *responseCache
+	if size := cfg.ResponseCacheSize(); size > 0 {
+		cache = newResponseCache(chainID, int(size), chainCfg.FinalityTagEnabled(), chainCfg.FinalityDepth())
+	}
+
 	for i, node := range nodes {
 		if node.SendOnly != nil && *node.SendOnly {
 			rpc := NewRPCClient(cfg, lggr, nil, node.HTTPURL.URL(), *node.Name, i, chainID,
 				multinode.Secondary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+			rpc.rateLimiter = newRateLimiter(chainID, node)
 			sendonly := multinode.NewSendOnlyNode(lggr, multiNodeMetrics, (url.URL)(*node.HTTPURL),
 				*node.Name, chainID, rpc)
 			sendonlys = append(sendonlys, sendonly)
 		} else {
 			rpc := NewRPCClient(cfg, lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i,
 				chainID, multinode.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+			if node.VerifyHeaders != nil && *node.VerifyHeaders {
+				rpc.headerVerifier = newHeaderVerifier(chainID, *node.Name, chainType)
+			}
+			rpc.responseCache = cache
+			rpc.rateLimiter = newRateLimiter(chainID, node)
 
 			primaryNode := multinode.NewNode(cfg, chainCfg,
 				lggr, multiNodeMetrics, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i, chainID, *node.Order,
@@ -45,8 +56,15 @@ func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, client
 		}
 	}
 
-	return NewChainClient(lggr, multiNodeMetrics, cfg.SelectionMode(), cfg.LeaseDuration(),
-		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType), nil
+	c := newChainClient(lggr, multiNodeMetrics, cfg.SelectionMode(), cfg.LeaseDuration(),
+		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType)
+	if n := cfg.QuorumNodes(); n > 0 {
+		c.quorum = &quorumConfig{nodes: int(n), threshold: int(cfg.QuorumThreshold())}
+	}
+	if cache != nil {
+		return &cachingClient{Client: c, cache: cache}, nil
+	}
+	return c, nil
 }
 
 func getRPCTimeouts(chainType chaintype.ChainType) (largePayload, defaultTimeout time.Duration) {
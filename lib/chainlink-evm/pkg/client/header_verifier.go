@@ -0,0 +1,112 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+var promEVMPoolRPCNodeHeadsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "evm_pool_rpc_node_heads_rejected",
+	Help: "The total number of heads from the given RPC node which failed header verification",
+}, []string{"evmChainID", "nodeName", "reason"})
+
+const (
+	headRejectedHash      = "hash"
+	headRejectedParent    = "parent"
+	headRejectedTimestamp = "timestamp"
+
+	// verifiedHeadsHistory is the number of recently verified heads kept to check the linkage of new heads.
+	verifiedHeadsHistory = 256
+)
+
+// headerHashVerifiable returns false for chains whose block hash isn't the Keccak256 hash of an Ethereum style RLP
+// encoded header, either because they use a different header format or derive hashes from another consensus layer.
+func headerHashVerifiable(chainType chaintype.ChainType) bool {
+	switch chainType {
+	case chaintype.ChainAstar, chaintype.ChainCelo, chaintype.ChainHedera, chaintype.ChainRootstock, chaintype.ChainSei,
+		chaintype.ChainTron, chaintype.ChainXLayer, chaintype.ChainZkEvm, chaintype.ChainZkSync:
+		return false
+	default:
+		return true
+	}
+}
+
+type verifiedHead struct {
+	number    int64
+	timestamp int64
+}
+
+// headerVerifier checks the heads returned by a single, possibly untrusted, RPC node. It recomputes the block hash
+// from the header fields where the chain type allows it, and checks number and timestamp against the parent if the
+// parent was verified recently.
+type headerVerifier struct {
+	chainID    *big.Int
+	nodeName   string
+	verifyHash bool
+
+	mu     sync.Mutex
+	recent map[common.Hash]verifiedHead
+}
+
+func newHeaderVerifier(chainID *big.Int, nodeName string, chainType chaintype.ChainType) *headerVerifier {
+	return &headerVerifier{
+		chainID:    chainID,
+		nodeName:   nodeName,
+		verifyHash: headerHashVerifiable(chainType),
+		recent:     make(map[common.Hash]verifiedHead),
+	}
+}
+
+// verify returns an error if head must be rejected, and counts the rejection.
+func (v *headerVerifier) verify(head *evmtypes.Head) error {
+	reason, err := v.check(head)
+	if err != nil {
+		promEVMPoolRPCNodeHeadsRejected.WithLabelValues(v.chainID.String(), v.nodeName, reason).Inc()
+		return fmt.Errorf("rejected head %s from node %s: %w", head, v.nodeName, err)
+	}
+	return nil
+}
+
+func (v *headerVerifier) check(head *evmtypes.Head) (reason string, err error) {
+	if v.verifyHash {
+		if err = head.VerifyHash(); err != nil {
+			return headRejectedHash, err
+		}
+	}
+	if head.Number > 0 && head.ParentHash == head.Hash {
+		return headRejectedParent, errors.New("head references itself as parent")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if parent, ok := v.recent[head.ParentHash]; ok {
+		if head.Number != parent.number+1 {
+			return headRejectedParent, fmt.Errorf("expected number %d for child of block %s, got %d", parent.number+1, head.ParentHash, head.Number)
+		}
+		if head.Timestamp.Unix() < parent.timestamp {
+			return headRejectedTimestamp, fmt.Errorf("timestamp %d is before parent timestamp %d", head.Timestamp.Unix(), parent.timestamp)
+		}
+	}
+	if known, ok := v.recent[head.Hash]; ok && known.number != head.Number {
+		return headRejectedParent, fmt.Errorf("block %s was previously returned with number %d", head.Hash, known.number)
+	}
+
+	v.recent[head.Hash] = verifiedHead{number: head.Number, timestamp: head.Timestamp.Unix()}
+	if len(v.recent) > verifiedHeadsHistory {
+		for hash, h := range v.recent {
+			if h.number <= head.Number-verifiedHeadsHistory {
+				delete(v.recent, hash)
+			}
+		}
+	}
+	return "", nil
+}
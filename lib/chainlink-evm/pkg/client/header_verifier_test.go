@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+)
+
+func TestHeaderVerifier(t *testing.T) {
+	t.Parallel()
+
+	newHead := func(t *testing.T, number int64, parent common.Hash, timestamp uint64) *evmtypes.Head {
+		bs, err := json.Marshal(&gethtypes.Header{
+			ParentHash: parent,
+			UncleHash:  gethtypes.EmptyUncleHash,
+			Difficulty: big.NewInt(0),
+			Number:     big.NewInt(number),
+			GasLimit:   30_000_000,
+			Time:       timestamp,
+			Extra:      []byte{},
+		})
+		require.NoError(t, err)
+		head := new(evmtypes.Head)
+		require.NoError(t, json.Unmarshal(bs, head))
+		return head
+	}
+	rejected := func(nodeName, reason string) int {
+		return int(testutil.ToFloat64(promEVMPoolRPCNodeHeadsRejected.WithLabelValues("1337", nodeName, reason)))
+	}
+
+	t.Run("checks hash and linkage", func(t *testing.T) {
+		v := newHeaderVerifier(big.NewInt(1337), "node-linkage", "")
+		h1 := newHead(t, 1, utils.NewHash(), 100)
+		require.NoError(t, v.verify(h1))
+		require.NoError(t, v.verify(newHead(t, 2, h1.Hash, 100)))
+
+		require.Error(t, v.verify(newHead(t, 3, h1.Hash, 112)))
+		assert.Equal(t, 1, rejected("node-linkage", headRejectedParent))
+
+		require.Error(t, v.verify(newHead(t, 2, h1.Hash, 99)))
+		assert.Equal(t, 1, rejected("node-linkage", headRejectedTimestamp))
+
+		forged := newHead(t, 2, h1.Hash, 112)
+		forged.Hash = utils.NewHash()
+		require.ErrorIs(t, v.verify(forged), evmtypes.ErrHeaderHashMismatch)
+		assert.Equal(t, 1, rejected("node-linkage", headRejectedHash))
+	})
+
+	t.Run("skips hash for unsupported chain types", func(t *testing.T) {
+		v := newHeaderVerifier(big.NewInt(1337), "node-zksync", chaintype.ChainZkSync)
+		h1 := newHead(t, 1, utils.NewHash(), 100)
+		h1.Hash = utils.NewHash()
+		require.NoError(t, v.verify(h1))
+
+		h2 := newHead(t, 2, h1.Hash, 90)
+		require.Error(t, v.verify(h2))
+		assert.Equal(t, 1, rejected("node-zksync", headRejectedTimestamp))
+	})
+
+	t.Run("rpc client drops rejected heads", func(t *testing.T) {
+		forged := newHead(t, 1, utils.NewHash(), 100)
+		forged.Hash = utils.NewHash()
+		jsonHead, err := json.Marshal(forged)
+		require.NoError(t, err)
+		wsURL := testutils.NewWSServer(t, big.NewInt(1337), func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			resp.Result = string(jsonHead)
+			return
+		}).WSURL()
+
+		ctx := tests.Context(t)
+		rpc := NewRPCClient(TestNodePoolConfig{}, logger.Test(t), wsURL, nil, "node-rpc", 1, big.NewInt(1337), multinode.Primary, QueryTimeout, QueryTimeout, "")
+		rpc.headerVerifier = newHeaderVerifier(big.NewInt(1337), "node-rpc", "")
+		require.NoError(t, rpc.Dial(ctx))
+		t.Cleanup(rpc.Close)
+
+		head, err := rpc.LatestSafeBlock(ctx)
+		require.ErrorContains(t, err, "rejected head")
+		assert.Nil(t, head)
+		head, err = rpc.BlockByHash(ctx, forged.Hash)
+		require.ErrorContains(t, err, "rejected head")
+		assert.Nil(t, head)
+		head, err = rpc.BlockByNumber(ctx, big.NewInt(1))
+		require.ErrorContains(t, err, "rejected head")
+		assert.Nil(t, head)
+	})
+}
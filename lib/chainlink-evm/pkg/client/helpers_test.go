@@ -99,6 +99,9 @@ type TestNodePoolConfig struct {
 	EnforceRepeatableReadVal       bool
 	NodeDeathDeclarationDelay      time.Duration
 	NodeNewHeadsPollInterval       time.Duration
+	NodeResponseCacheSize          uint32
+	NodeQuorumNodes                uint32
+	NodeQuorumThreshold            uint32
 }
 
 func (tc TestNodePoolConfig) PollFailureThreshold() uint32 { return tc.NodePollFailureThreshold }
@@ -125,6 +128,18 @@ func (tc TestNodePoolConfig) VerifyChainID() bool {
 	return true
 }
 
+func (tc TestNodePoolConfig) ResponseCacheSize() uint32 {
+	return tc.NodeResponseCacheSize
+}
+
+func (tc TestNodePoolConfig) QuorumNodes() uint32 {
+	return tc.NodeQuorumNodes
+}
+
+func (tc TestNodePoolConfig) QuorumThreshold() uint32 {
+	return tc.NodeQuorumThreshold
+}
+
 func (tc TestNodePoolConfig) Errors() config.ClientErrors {
 	return tc.NodeErrors
 }
@@ -236,6 +236,21 @@ func (nc *NullClient) LatestSafeBlock(_ context.Context) (*evmtypes.Head, error)
 	return nil, nil
 }
 
+func (nc *NullClient) TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error) {
+	nc.lggr.Debug("TraceTransaction")
+	return nil, nil
+}
+
+func (nc *NullClient) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error) {
+	nc.lggr.Debug("TraceCall")
+	return nil, nil
+}
+
+func (nc *NullClient) TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (PrestateTrace, error) {
+	nc.lggr.Debug("TraceTransactionPrestate")
+	return nil, nil
+}
+
 func (nc *NullClient) CheckTxValidity(_ context.Context, _ common.Address, _ common.Address, _ []byte) *SendError {
 	return nil
 }
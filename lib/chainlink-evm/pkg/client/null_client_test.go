@@ -162,4 +162,25 @@ func TestNullClient(t *testing.T) {
 		m := nc.NodeStates()
 		require.Nil(t, m)
 	})
+
+	t.Run("Trace methods", func(t *testing.T) {
+		lggr, logs := logger.TestObserved(t, zapcore.DebugLevel)
+		nc := client.NewNullClient(nil, lggr)
+		ctx := tests.Context(t)
+
+		frame, err := nc.TraceTransaction(ctx, common.Hash{})
+		require.NoError(t, err)
+		require.Nil(t, frame)
+		require.Equal(t, 1, logs.FilterMessage("TraceTransaction").Len())
+
+		frame, err = nc.TraceCall(ctx, ethereum.CallMsg{}, nil)
+		require.NoError(t, err)
+		require.Nil(t, frame)
+		require.Equal(t, 1, logs.FilterMessage("TraceCall").Len())
+
+		prestate, err := nc.TraceTransactionPrestate(ctx, common.Hash{})
+		require.NoError(t, err)
+		require.Nil(t, prestate)
+		require.Equal(t, 1, logs.FilterMessage("TraceTransactionPrestate").Len())
+	})
 }
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var promEVMClientQuorumDisagreements = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "evm_client_quorum_read_disagreements",
+	Help: "The total number of quorum reads for which the given RPC node failed or returned a result other than the quorum",
+}, []string{"evmChainID", "method", "nodeName"})
+
+// quorumConfig enables quorum reads, which query up to nodes alive nodes and require threshold of them to agree.
+// Threshold is validated to be a majority of nodes, so at most one result can reach it.
+type quorumConfig struct {
+	nodes     int
+	threshold int
+}
+
+// QuorumResponse is the response of a single node to a quorum read.
+type QuorumResponse struct {
+	Node   string
+	Result string // printable form of the result, empty if Err is set
+	Err    error
+}
+
+func (r QuorumResponse) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s: %v", r.Node, r.Err)
+	}
+	return fmt.Sprintf("%s: %s", r.Node, r.Result)
+}
+
+// QuorumError is returned by quorum reads if fewer than Threshold nodes returned the same result.
+type QuorumError struct {
+	Method    string
+	Threshold int
+	Responses []QuorumResponse
+}
+
+func (e *QuorumError) Error() string {
+	responses := make([]string, len(e.Responses))
+	for i, r := range e.Responses {
+		responses[i] = r.String()
+	}
+	return fmt.Sprintf("%s: fewer than %d of %d nodes agreed on the result: %s", e.Method, e.Threshold, len(e.Responses),
+		strings.Join(responses, "; "))
+}
+
+// quorumRead calls read concurrently on up to c.quorum.nodes alive primary nodes, starting with selected, and returns
+// the result that at least c.quorum.threshold of them agree on. Results are compared by the string returned by
+// format. Nodes which failed or disagreed with the quorum are logged and counted.
+func quorumRead[T any](ctx context.Context, c *chainClient, selected *RPCClient, method string,
+	read func(context.Context, *RPCClient) (T, error), format func(T) string) (result T, err error) {
+	rpcs := []*RPCClient{selected}
+	err = c.multiNode.DoAll(ctx, func(_ context.Context, rpc *RPCClient, isSendOnly bool) {
+		if !isSendOnly && rpc != selected && len(rpcs) < c.quorum.nodes {
+			rpcs = append(rpcs, rpc)
+		}
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(rpcs) < c.quorum.threshold {
+		return result, fmt.Errorf("%s: quorum of %d nodes required, but only %d are alive", method, c.quorum.threshold, len(rpcs))
+	}
+
+	results := make([]T, len(rpcs))
+	responses := make([]QuorumResponse, len(rpcs))
+	var wg sync.WaitGroup
+	for i, rpc := range rpcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i].Node = rpc.Name()
+			results[i], responses[i].Err = read(ctx, rpc)
+			if responses[i].Err == nil {
+				responses[i].Result = format(results[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	votes := make(map[string]int)
+	agreed := -1
+	for i, r := range responses {
+		if r.Err != nil {
+			continue
+		}
+		votes[r.Result]++
+		if votes[r.Result] == c.quorum.threshold {
+			agreed = i
+		}
+	}
+	if agreed < 0 {
+		return result, &QuorumError{Method: method, Threshold: c.quorum.threshold, Responses: responses}
+	}
+
+	var disagreed bool
+	for _, r := range responses {
+		if r.Err != nil || r.Result != responses[agreed].Result {
+			disagreed = true
+			promEVMClientQuorumDisagreements.WithLabelValues(c.multiNode.ChainID().String(), method, r.Node).Inc()
+		}
+	}
+	if disagreed {
+		c.logger.Warnw("Some nodes failed or disagreed with the quorum", "method", method, "quorum", responses[agreed].Result,
+			"responses", responses)
+	}
+	return results[agreed], nil
+}
@@ -0,0 +1,154 @@
+package client_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+func TestChainClient_QuorumReads(t *testing.T) {
+	t.Parallel()
+
+	const pinnedHash = "0x41800b5c3f1717687d85fc9018faac0a6e90b39deaa0b99e7fe4fe796ddeb26a"
+	chainID := big.NewInt(4545)
+	address := testutils.NewAddress()
+
+	// each node answers reads at the pinned block with its own balance, call result and block hash
+	type nodeAnswers struct {
+		balance string
+		call    string
+		hash    string
+	}
+	answers := map[string]nodeAnswers{
+		"a": {balance: "0x100", call: "0x01", hash: pinnedHash},
+		"b": {balance: "0x100", call: "0x02", hash: pinnedHash},
+		"c": {balance: "0x999", call: "", hash: "0x" + strings.Repeat("ab", 32)},
+	}
+	var nodes []*toml.Node
+	for _, name := range []string{"a", "b", "c"} {
+		node := answers[name]
+		wsURL := testutils.NewWSServer(t, chainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			atPinnedBlock := params.Get("1.blockHash").String() == pinnedHash
+			switch method {
+			case "eth_subscribe":
+				resp.Result = `"0x00"`
+				resp.Notify = headResult
+			case "eth_unsubscribe":
+				resp.Result = "true"
+			case "eth_getBlockByNumber":
+				resp.Result = headResult
+				if params.Get("0").String() == "0x1" {
+					resp.Result = strings.Replace(headResult, pinnedHash, node.hash, 1)
+				}
+			case "eth_getBalance":
+				if assert.True(t, atPinnedBlock) {
+					resp.Result = `"` + node.balance + `"`
+				}
+			case "eth_call":
+				if !assert.True(t, atPinnedBlock) {
+					return
+				}
+				if node.call == "" {
+					resp.Error.Message = "execution reverted"
+					return
+				}
+				resp.Result = `"` + node.call + `"`
+			}
+			return
+		}).WSURL()
+		nodes = append(nodes, &toml.Node{
+			Name:  ptr(name),
+			WSURL: commonconfig.MustParseURL(wsURL.String()),
+			Order: ptr[int32](1),
+		})
+	}
+
+	nodePool := client.TestNodePoolConfig{
+		NodeSelectionMode:              multinode.NodeSelectionModeRoundRobin,
+		NodeFinalizedBlockPollInterval: time.Second,
+		NodeQuorumNodes:                3,
+		NodeQuorumThreshold:            2,
+	}
+	chainCfg, _, _, err := client.NewClientConfigs(ptr(multinode.NodeSelectionModeRoundRobin), 0, "", nil,
+		ptr[uint32](5), 0, ptr[uint32](0), ptr(false), 0, ptr[uint32](5), ptr(false),
+		ptr[uint32](0), ptr(false), 0, 0, time.Second, 0, time.Minute)
+	require.NoError(t, err)
+	evmClient, err := client.NewEvmClient(nodePool, chainCfg, nil, logger.Test(t), chainID, nodes, "")
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+	require.NoError(t, evmClient.Dial(ctx))
+	t.Cleanup(evmClient.Close)
+	require.Eventually(t, func() bool {
+		for _, state := range evmClient.NodeStates() {
+			if state != "Alive" {
+				return false
+			}
+		}
+		return true
+	}, tests.WaitTimeout(t), 100*time.Millisecond, "nodes are not alive")
+
+	t.Run("BalanceAt", func(t *testing.T) {
+		balance, err := evmClient.BalanceAt(ctx, address, nil)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0x100), balance)
+		assert.Equal(t, 1, disagreements(t, chainID, "BalanceAt", "c"))
+	})
+
+	t.Run("CallContract", func(t *testing.T) {
+		_, err := evmClient.CallContract(ctx, ethereum.CallMsg{To: &address}, nil)
+		var quorumErr *client.QuorumError
+		require.ErrorAs(t, err, &quorumErr)
+		assert.Equal(t, 2, quorumErr.Threshold)
+		responses := make(map[string]client.QuorumResponse)
+		for _, r := range quorumErr.Responses {
+			responses[r.Node] = r
+		}
+		assert.Equal(t, "0x01", responses["a"].Result)
+		assert.Equal(t, "0x02", responses["b"].Result)
+		assert.ErrorContains(t, responses["c"].Err, "execution reverted")
+	})
+
+	t.Run("HeadByNumber", func(t *testing.T) {
+		head, err := evmClient.HeadByNumber(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash(pinnedHash), head.Hash)
+		assert.Equal(t, 1, disagreements(t, chainID, "HeadByNumber", "c"))
+	})
+}
+
+func disagreements(t *testing.T, chainID *big.Int, method, nodeName string) int {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "evm_client_quorum_read_disagreements" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["evmChainID"] == chainID.String() && labels["method"] == method && labels["nodeName"] == nodeName {
+				return int(m.GetCounter().GetValue())
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tidwall/gjson"
+	"golang.org/x/time/rate"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
+)
+
+var (
+	promEVMPoolRPCNodeRateLimiterTokens = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "evm_pool_rpc_node_rate_limiter_tokens",
+		Help: "The number of calls the given RPC node's rate limiter budget allowed without waiting, after the latest request was admitted",
+	}, []string{"evmChainID", "nodeName", "budget"})
+	promEVMPoolRPCNodeRateLimiterWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "evm_pool_rpc_node_rate_limiter_wait_seconds",
+		Help:    "The time requests to the given RPC node were queued by its rate limiter budget",
+		Buckets: []float64{0, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+	}, []string{"evmChainID", "nodeName", "budget"})
+	promEVMPoolRPCNodeRateLimiterRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_node_rate_limiter_rejected_total",
+		Help: "The total number of requests to the given RPC node which failed because its rate limiter budget would have delayed them past their context deadline",
+	}, []string{"evmChainID", "nodeName", "budget"})
+)
+
+const (
+	rateLimitBudgetAll   = "all"
+	rateLimitBudgetHeavy = "heavy"
+)
+
+// heavyMethods are the methods which count towards a node's HeavyRequestsPerSecond budget, as providers typically
+// bill them at a multiple of other calls.
+var heavyMethods = map[string]bool{
+	"eth_getLogs":          true,
+	"eth_getBlockReceipts": true,
+}
+
+// rateLimiter throttles the JSON-RPC calls sent to a single node over HTTP, which carries all calls except
+// subscriptions. Every call counts towards the node's overall budget, including each element of a batch, and heavy
+// methods also count towards a separate budget. Requests wait until all their budgets allow them, or fail if that
+// would exceed their context deadline.
+type rateLimiter struct {
+	chainID  string
+	nodeName string
+	all      *rate.Limiter // nil if the overall budget is unlimited
+	heavy    *rate.Limiter // nil if the heavy budget is unlimited
+}
+
+// newRateLimiter returns nil if no budget is configured for node.
+func newRateLimiter(chainID *big.Int, node *toml.Node) *rateLimiter {
+	l := &rateLimiter{chainID: chainID.String(), nodeName: *node.Name}
+	if node.RequestsPerSecond != nil && *node.RequestsPerSecond > 0 {
+		burst := *node.RequestsPerSecond
+		if node.RequestBurst != nil && *node.RequestBurst > 0 {
+			burst = *node.RequestBurst
+		}
+		l.all = rate.NewLimiter(rate.Limit(*node.RequestsPerSecond), int(burst))
+	}
+	if node.HeavyRequestsPerSecond != nil && *node.HeavyRequestsPerSecond > 0 {
+		l.heavy = rate.NewLimiter(rate.Limit(*node.HeavyRequestsPerSecond), int(*node.HeavyRequestsPerSecond))
+	}
+	if l.all == nil && l.heavy == nil {
+		return nil
+	}
+	return l
+}
+
+// httpClient returns an HTTP client which applies the rate limits to every request before sending it.
+func (l *rateLimiter) httpClient() *http.Client {
+	return &http.Client{Transport: &rateLimitedTransport{limiter: l, next: http.DefaultTransport}}
+}
+
+// admit blocks until both budgets allow a request of the given number of calls, heavy ones included. Tokens are
+// reserved from both budgets upfront, and given back to both if the request is rejected, so a request that can't be
+// admitted by one budget doesn't use up the other.
+func (l *rateLimiter) admit(req *http.Request, calls, heavy int) error {
+	now := time.Now()
+	budgets := []struct {
+		name    string
+		limiter *rate.Limiter
+		res     []*rate.Reservation
+		delay   time.Duration
+	}{
+		{name: rateLimitBudgetHeavy, limiter: l.heavy, res: reserve(l.heavy, heavy, now)},
+		{name: rateLimitBudgetAll, limiter: l.all, res: reserve(l.all, calls, now)},
+	}
+	var delay time.Duration
+	for i := range budgets {
+		if n := len(budgets[i].res); n > 0 {
+			budgets[i].delay = budgets[i].res[n-1].DelayFrom(now)
+			delay = max(delay, budgets[i].delay)
+		}
+	}
+
+	reject := func(err error) error {
+		for _, b := range budgets {
+			if len(b.res) == 0 {
+				continue
+			}
+			for i := len(b.res) - 1; i >= 0; i-- {
+				b.res[i].CancelAt(now)
+			}
+			if b.delay == delay {
+				promEVMPoolRPCNodeRateLimiterRejected.WithLabelValues(l.chainID, l.nodeName, b.name).Inc()
+			}
+		}
+		return fmt.Errorf("rate limit of node %s: %w", l.nodeName, err)
+	}
+	ctx := req.Context()
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		return reject(fmt.Errorf("waiting %s would exceed context deadline", delay))
+	}
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return reject(ctx.Err())
+		}
+	}
+
+	for _, b := range budgets {
+		if len(b.res) == 0 {
+			continue
+		}
+		promEVMPoolRPCNodeRateLimiterWait.WithLabelValues(l.chainID, l.nodeName, b.name).Observe(b.delay.Seconds())
+		promEVMPoolRPCNodeRateLimiterTokens.WithLabelValues(l.chainID, l.nodeName, b.name).Set(b.limiter.Tokens())
+	}
+	return nil
+}
+
+// reserve reserves n calls from limiter, in chunks of at most its burst size. It returns no reservations if limiter is
+// nil, i.e. the budget is unlimited.
+func reserve(limiter *rate.Limiter, n int, now time.Time) []*rate.Reservation {
+	if limiter == nil {
+		return nil
+	}
+	var res []*rate.Reservation
+	for n > 0 {
+		chunk := min(n, limiter.Burst())
+		res = append(res, limiter.ReserveN(now, chunk))
+		n -= chunk
+	}
+	return res
+}
+
+type rateLimitedTransport struct {
+	limiter *rateLimiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if cerr := req.Body.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, err
+		}
+		calls, heavy := countCalls(body)
+		if err = t.limiter.admit(req, calls, heavy); err != nil {
+			return nil, err
+		}
+		// a RoundTripper must not modify the request, so the body is restored on a copy
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return t.next.RoundTrip(req)
+}
+
+// countCalls returns the number of JSON-RPC calls in a request body, and how many of them are heavy.
+func countCalls(body []byte) (calls int, heavy int) {
+	msg := gjson.ParseBytes(body)
+	if !msg.IsArray() {
+		if heavyMethods[msg.Get("method").String()] {
+			return 1, 1
+		}
+		return 1, 0
+	}
+	for _, el := range msg.Array() {
+		calls++
+		if heavyMethods[el.Get("method").String()] {
+			heavy++
+		}
+	}
+	return calls, heavy
+}
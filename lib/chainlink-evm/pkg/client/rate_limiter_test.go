@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+func TestCountCalls(t *testing.T) {
+	t.Parallel()
+
+	calls, heavy := countCalls([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, heavy)
+
+	calls, heavy = countCalls([]byte(`[{"method":"eth_blockNumber"},{"method":"eth_getLogs"},{"method":"eth_getBlockReceipts"}]`))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2, heavy)
+}
+
+func TestRPCClient_RateLimiter(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.NewHTTPServer(t, nil, func(method string, _ gjson.Result) (resp testutils.JSONRPCResponse) {
+		switch method {
+		case "eth_blockNumber":
+			resp.Result = `"0x10"`
+		case "eth_getLogs":
+			resp.Result = `[]`
+		}
+		return
+	})
+	httpURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	newClient := func(t *testing.T, nodeName string, rps, burst, heavyRPS uint32) *RPCClient {
+		chainID := testutils.FixtureChainID
+		r := NewRPCClient(TestNodePoolConfig{}, logger.Test(t), nil, httpURL, nodeName, 1, chainID, multinode.Primary, QueryTimeout, QueryTimeout, "")
+		r.rateLimiter = newRateLimiter(chainID, &toml.Node{Name: &nodeName, RequestsPerSecond: &rps, RequestBurst: &burst, HeavyRequestsPerSecond: &heavyRPS})
+		require.NoError(t, r.DialHTTP())
+		return r
+	}
+	shortCtx := func(t *testing.T) context.Context {
+		ctx, cancel := context.WithTimeout(testutils.Context(t), 100*time.Millisecond)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newRateLimiter(testutils.FixtureChainID, &toml.Node{Name: ptr("node")}))
+	})
+
+	t.Run("rejects requests past their deadline", func(t *testing.T) {
+		r := newClient(t, "limited", 1, 2, 0)
+		ctx := testutils.Context(t)
+		for range 2 {
+			_, err := r.BlockNumber(ctx)
+			require.NoError(t, err)
+		}
+		_, err := r.BlockNumber(shortCtx(t))
+		require.ErrorContains(t, err, "rate limit of node limited")
+		assert.Equal(t, float64(1), testutil.ToFloat64(promEVMPoolRPCNodeRateLimiterRejected.WithLabelValues(testutils.FixtureChainID.String(), "limited", rateLimitBudgetAll)))
+
+		// requests queue until the budget allows them
+		start := time.Now()
+		_, err = r.BlockNumber(ctx)
+		require.NoError(t, err)
+		assert.Greater(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("batches count every call", func(t *testing.T) {
+		r := newClient(t, "batched", 1, 3, 0)
+		batch := make([]rpc.BatchElem, 3)
+		for i := range batch {
+			batch[i] = rpc.BatchElem{Method: "eth_blockNumber", Result: new(string)}
+		}
+		require.NoError(t, r.BatchCallContext(testutils.Context(t), batch))
+		_, err := r.BlockNumber(shortCtx(t))
+		require.ErrorContains(t, err, "rate limit of node batched")
+	})
+
+	t.Run("heavy methods have a separate budget", func(t *testing.T) {
+		r := newClient(t, "heavy", 100, 100, 1)
+		_, err := r.FilterLogs(testutils.Context(t), ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2)})
+		require.NoError(t, err)
+		_, err = r.FilterLogs(shortCtx(t), ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2)})
+		require.ErrorContains(t, err, "rate limit of node heavy")
+		_, err = r.BlockNumber(shortCtx(t))
+		require.NoError(t, err)
+	})
+
+	t.Run("rejected requests don't use up the other budget", func(t *testing.T) {
+		r := newClient(t, "both", 2, 1, 1)
+		_, err := r.BlockNumber(testutils.Context(t))
+		require.NoError(t, err)
+
+		// the overall budget rejects the request, so the heavy budget must still allow the next one
+		_, err = r.FilterLogs(shortCtx(t), ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2)})
+		require.ErrorContains(t, err, "rate limit of node both")
+		time.Sleep(500 * time.Millisecond)
+		_, err = r.FilterLogs(shortCtx(t), ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2)})
+		require.NoError(t, err)
+	})
+}
+
+func ptr[T any](t T) *T { return &t }
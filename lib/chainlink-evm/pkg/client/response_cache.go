@@ -0,0 +1,219 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+var (
+	promEVMClientResponseCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_client_response_cache_hits",
+		Help: "The total number of client requests served from the response cache",
+	}, []string{"evmChainID", "method"})
+	promEVMClientResponseCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_client_response_cache_misses",
+		Help: "The total number of cacheable client requests which were not found in the response cache",
+	}, []string{"evmChainID", "method"})
+)
+
+const (
+	cachedHeadByHash         = "HeadByHash"
+	cachedBlockByHash        = "BlockByHash"
+	cachedTransactionReceipt = "TransactionReceipt"
+	cachedCodeAt             = "CodeAt"
+
+	// reorgTrackedHeads is the maximum number of recent non-finalized heads remembered to detect reorgs.
+	reorgTrackedHeads = 256
+)
+
+type responseCacheKey struct {
+	method  string
+	hash    common.Hash // block or transaction hash
+	address common.Address
+	number  int64
+}
+
+type responseCacheEntry struct {
+	blockNumber int64
+	value       any
+}
+
+// responseCache is an LRU cache of the client responses which can't change once their block is finalized. Responses
+// looked up by block hash are cached before their block is finalized as well, but are dropped when a reorg is
+// observed, since the RPCs may stop serving blocks which were reorged out.
+//
+// The latest and finalized heads seen by the RPC clients are fed to the cache with observeHead and observeFinalized.
+// A nil *responseCache ignores them.
+type responseCache struct {
+	chainID            string
+	finalityTagEnabled bool
+	finalityDepth      int64
+
+	mu        sync.Mutex
+	entries   lru.BasicLRU[responseCacheKey, responseCacheEntry]
+	finalized int64
+	recent    map[int64]common.Hash // hashes of recent non-finalized heads by number
+}
+
+func newResponseCache(chainID *big.Int, size int, finalityTagEnabled bool, finalityDepth uint32) *responseCache {
+	return &responseCache{
+		chainID:            chainID.String(),
+		finalityTagEnabled: finalityTagEnabled,
+		finalityDepth:      int64(finalityDepth),
+		entries:            lru.NewBasicLRU[responseCacheKey, responseCacheEntry](size),
+		recent:             make(map[int64]common.Hash),
+	}
+}
+
+func (c *responseCache) get(key responseCacheKey) (any, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries.Get(key)
+	c.mu.Unlock()
+	if !ok {
+		promEVMClientResponseCacheMisses.WithLabelValues(c.chainID, key.method).Inc()
+		return nil, false
+	}
+	promEVMClientResponseCacheHits.WithLabelValues(c.chainID, key.method).Inc()
+	return entry.value, true
+}
+
+func (c *responseCache) add(key responseCacheKey, blockNumber int64, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Add(key, responseCacheEntry{blockNumber: blockNumber, value: value})
+}
+
+// isFinalized returns true if blockNumber is at or below the highest finalized block observed.
+func (c *responseCache) isFinalized(blockNumber int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return blockNumber <= c.finalized
+}
+
+// observeHead records a new latest head. If it conflicts with a recently observed head, the entries of blocks which
+// aren't finalized are dropped.
+func (c *responseCache) observeHead(head *evmtypes.Head) {
+	if c == nil || head == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.finalityTagEnabled {
+		c.setFinalizedLocked(head.Number - c.finalityDepth)
+	}
+
+	known, ok := c.recent[head.Number]
+	reorged := ok && known != head.Hash
+	if parent, ok := c.recent[head.Number-1]; ok && parent != head.ParentHash {
+		reorged = true
+	}
+	if reorged {
+		for _, key := range c.entries.Keys() {
+			if entry, ok := c.entries.Peek(key); ok && entry.blockNumber > c.finalized {
+				c.entries.Remove(key)
+			}
+		}
+		clear(c.recent)
+	}
+
+	c.recent[head.Number] = head.Hash
+	for number := range c.recent {
+		if number <= c.finalized || number <= head.Number-reorgTrackedHeads {
+			delete(c.recent, number)
+		}
+	}
+}
+
+// observeFinalized records a new latest finalized head.
+func (c *responseCache) observeFinalized(head *evmtypes.Head) {
+	if c == nil || head == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setFinalizedLocked(head.Number)
+}
+
+func (c *responseCache) setFinalizedLocked(number int64) {
+	if number > c.finalized {
+		c.finalized = number
+	}
+}
+
+// cachingClient serves the responses which are safe to reuse from a responseCache, and delegates everything else to
+// the wrapped Client.
+type cachingClient struct {
+	Client
+	cache *responseCache
+}
+
+func (c *cachingClient) HeadByHash(ctx context.Context, hash common.Hash) (*evmtypes.Head, error) {
+	key := responseCacheKey{method: cachedHeadByHash, hash: hash}
+	if v, ok := c.cache.get(key); ok {
+		return v.(*evmtypes.Head).Copy(), nil
+	}
+	head, err := c.Client.HeadByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	// callers may link the head into a chain, so only copies are shared
+	c.cache.add(key, head.Number, head.Copy())
+	return head, nil
+}
+
+func (c *cachingClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	key := responseCacheKey{method: cachedBlockByHash, hash: hash}
+	if v, ok := c.cache.get(key); ok {
+		return v.(*types.Block), nil
+	}
+	block, err := c.Client.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.add(key, block.Number().Int64(), block)
+	return block, nil
+}
+
+func (c *cachingClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	key := responseCacheKey{method: cachedTransactionReceipt, hash: txHash}
+	if v, ok := c.cache.get(key); ok {
+		receipt := *v.(*types.Receipt)
+		return &receipt, nil
+	}
+	receipt, err := c.Client.TransactionReceipt(ctx, txHash)
+	if err != nil || receipt == nil || receipt.BlockNumber == nil {
+		return receipt, err
+	}
+	// the transaction may still be reorged into another block, until its block is finalized
+	if blockNumber := receipt.BlockNumber.Int64(); c.cache.isFinalized(blockNumber) {
+		cached := *receipt
+		c.cache.add(key, blockNumber, &cached)
+	}
+	return receipt, nil
+}
+
+func (c *cachingClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber == nil || blockNumber.Sign() < 0 || !c.cache.isFinalized(blockNumber.Int64()) {
+		return c.Client.CodeAt(ctx, account, blockNumber)
+	}
+	key := responseCacheKey{method: cachedCodeAt, address: account, number: blockNumber.Int64()}
+	if v, ok := c.cache.get(key); ok {
+		return bytes.Clone(v.([]byte)), nil
+	}
+	code, err := c.Client.CodeAt(ctx, account, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.add(key, blockNumber.Int64(), bytes.Clone(code))
+	return code, nil
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+)
+
+// countingClient serves heads, receipts and code from maps, and counts the requests it receives.
+type countingClient struct {
+	Client
+	heads    map[common.Hash]*evmtypes.Head
+	receipts map[common.Hash]*gethtypes.Receipt
+	code     []byte
+	calls    map[string]int
+}
+
+func (c *countingClient) HeadByHash(_ context.Context, hash common.Hash) (*evmtypes.Head, error) {
+	c.calls[cachedHeadByHash]++
+	return c.heads[hash], nil
+}
+
+func (c *countingClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*gethtypes.Receipt, error) {
+	c.calls[cachedTransactionReceipt]++
+	return c.receipts[txHash], nil
+}
+
+func (c *countingClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	c.calls[cachedCodeAt]++
+	return c.code, nil
+}
+
+func TestCachingClient(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(chainID int64, finalityTagEnabled bool) (*cachingClient, *countingClient) {
+		wrapped := &countingClient{
+			heads:    make(map[common.Hash]*evmtypes.Head),
+			receipts: make(map[common.Hash]*gethtypes.Receipt),
+			calls:    make(map[string]int),
+		}
+		return &cachingClient{Client: wrapped, cache: newResponseCache(big.NewInt(chainID), 100, finalityTagEnabled, 5)}, wrapped
+	}
+	newHead := func(number int64, parent common.Hash) *evmtypes.Head {
+		head := evmtypes.NewHead(big.NewInt(number), utils.NewHash(), parent, nil)
+		return &head
+	}
+
+	t.Run("reuses heads by hash until a reorg", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutils.Context(t)
+		c, wrapped := newClient(1001, true)
+		head1 := newHead(1, utils.NewHash())
+		head2 := newHead(2, head1.Hash)
+		wrapped.heads[head1.Hash] = head1
+		wrapped.heads[head2.Hash] = head2
+		c.cache.observeFinalized(head1)
+		c.cache.observeHead(head2)
+
+		for range 2 {
+			head, err := c.HeadByHash(ctx, head2.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, head2.Hash, head.Hash)
+			// a cached head isn't shared with the caller
+			head.Parent.Store(head1)
+			_, err = c.HeadByHash(ctx, head1.Hash)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, wrapped.calls[cachedHeadByHash])
+		assert.Equal(t, float64(2), testutil.ToFloat64(promEVMClientResponseCacheHits.WithLabelValues("1001", cachedHeadByHash)))
+		assert.Equal(t, float64(2), testutil.ToFloat64(promEVMClientResponseCacheMisses.WithLabelValues("1001", cachedHeadByHash)))
+		cached, err := c.HeadByHash(ctx, head2.Hash)
+		require.NoError(t, err)
+		assert.Nil(t, cached.Parent.Load())
+
+		// another head at the same height drops the non-finalized head, but keeps the finalized one
+		c.cache.observeHead(newHead(2, head1.Hash))
+		_, err = c.HeadByHash(ctx, head2.Hash)
+		require.NoError(t, err)
+		_, err = c.HeadByHash(ctx, head1.Hash)
+		require.NoError(t, err)
+		assert.Equal(t, 3, wrapped.calls[cachedHeadByHash])
+	})
+
+	t.Run("only caches receipts of finalized transactions", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutils.Context(t)
+		c, wrapped := newClient(1002, false)
+		txHash := utils.NewHash()
+		wrapped.receipts[txHash] = &gethtypes.Receipt{TxHash: txHash, BlockNumber: big.NewInt(10)}
+
+		c.cache.observeHead(newHead(14, utils.NewHash()))
+		_, err := c.TransactionReceipt(ctx, txHash)
+		require.NoError(t, err)
+		_, err = c.TransactionReceipt(ctx, txHash)
+		require.NoError(t, err)
+		assert.Equal(t, 2, wrapped.calls[cachedTransactionReceipt])
+
+		// block 10 is finalized at depth 5 once block 15 is observed
+		c.cache.observeHead(newHead(15, utils.NewHash()))
+		for range 3 {
+			receipt, err := c.TransactionReceipt(ctx, txHash)
+			require.NoError(t, err)
+			assert.Equal(t, txHash, receipt.TxHash)
+		}
+		assert.Equal(t, 3, wrapped.calls[cachedTransactionReceipt])
+	})
+
+	t.Run("only caches code at finalized blocks", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutils.Context(t)
+		c, wrapped := newClient(1003, true)
+		wrapped.code = []byte{0x60, 0x80}
+		account := testutils.NewAddress()
+		c.cache.observeFinalized(newHead(10, utils.NewHash()))
+
+		for _, blockNumber := range []*big.Int{nil, big.NewInt(11), big.NewInt(11)} {
+			_, err := c.CodeAt(ctx, account, blockNumber)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, wrapped.calls[cachedCodeAt])
+
+		for range 3 {
+			code, err := c.CodeAt(ctx, account, big.NewInt(10))
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0x60, 0x80}, code)
+			code[0] = 0
+		}
+		assert.Equal(t, 4, wrapped.calls[cachedCodeAt])
+	})
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RevertErrors is the registry used to decode the revert data of calls, simulations and transactions. Contracts with
+// custom errors should register their ABI, e.g. from a gethwrapper:
+//
+//	err := client.RevertErrors.RegisterMetaData(my_contract.MyContractMetaData)
+var RevertErrors = NewRevertRegistry()
+
+// DecodeRevert decodes the revert data carried by err with RevertErrors. It returns nil if err carries no revert data,
+// or if the data does not match a registered error.
+func DecodeRevert(err error) *DecodedRevert {
+	return RevertErrors.DecodeError(err)
+}
+
+// DecodedRevert is revert data decoded with the ABI of the error which produced it.
+type DecodedRevert struct {
+	// Name is the name of the error, e.g. Error for require and revert with a reason, or Panic for failed assertions.
+	Name string
+	// Signature is the canonical signature of the error, e.g. Error(string).
+	Signature string
+	Args      []RevertArg
+	// Reason is the reason of Error and Panic reverts, and empty for custom errors.
+	Reason string
+}
+
+// RevertArg is an argument of a decoded revert. Name is empty for unnamed arguments.
+type RevertArg struct {
+	Name  string
+	Value interface{}
+}
+
+// String formats the revert like a Solidity error, e.g. InsufficientBalance(available=1, required=2). Panics include
+// the reason for their code.
+func (d *DecodedRevert) String() string {
+	args := make([]string, len(d.Args))
+	for i, arg := range d.Args {
+		args[i] = formatRevertArg(arg.Value)
+		if arg.Name != "" {
+			args[i] = arg.Name + "=" + args[i]
+		}
+	}
+	s := fmt.Sprintf("%s(%s)", d.Name, strings.Join(args, ", "))
+	if d.Signature == panicSignature {
+		s += ": " + d.Reason
+	}
+	return s
+}
+
+func formatRevertArg(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		return hexutil.Encode(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	// fixed size bytes are decoded into arrays
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+const panicSignature = "Panic(uint256)"
+
+// RevertRegistry decodes revert data with the errors of registered ABIs. Error(string) and Panic(uint256), which the
+// Solidity compiler emits for require, revert and failed assertions, are always registered.
+type RevertRegistry struct {
+	mu     sync.RWMutex
+	errors map[[4]byte]abi.Error
+}
+
+func NewRevertRegistry() *RevertRegistry {
+	r := &RevertRegistry{errors: make(map[[4]byte]abi.Error)}
+	stringType, _ := abi.NewType("string", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	for _, e := range []abi.Error{
+		abi.NewError("Error", abi.Arguments{{Type: stringType}}),
+		abi.NewError("Panic", abi.Arguments{{Type: uint256Type}}),
+	} {
+		// NewError names unnamed arguments arg0, arg1, etc.
+		e.Inputs[0].Name = ""
+		_ = r.add(e)
+	}
+	return r
+}
+
+// Register adds the errors of contractABI to the registry. Errors whose selector is already registered for another
+// signature are skipped and returned as an error, since their revert data would be decoded as the other error. The
+// remaining errors are still registered.
+func (r *RevertRegistry) Register(contractABI *abi.ABI) error {
+	var errs error
+	for _, e := range contractABI.Errors {
+		errs = errors.Join(errs, r.add(e))
+	}
+	return errs
+}
+
+// RegisterJSON adds the errors of the JSON encoded ABI to the registry.
+func (r *RevertRegistry) RegisterJSON(abiJSON string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	return r.Register(&parsed)
+}
+
+// RegisterMetaData adds the errors of a gethwrapper's contract to the registry.
+func (r *RevertRegistry) RegisterMetaData(metaData *bind.MetaData) error {
+	parsed, err := metaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	return r.Register(parsed)
+}
+
+func (r *RevertRegistry) add(e abi.Error) error {
+	var selector [4]byte
+	copy(selector[:], e.ID[:4])
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.errors[selector]; ok && existing.Sig != e.Sig {
+		return fmt.Errorf("selector %s of %s is already registered for %s", hexutil.Encode(selector[:]), e.Sig, existing.Sig)
+	}
+	r.errors[selector] = e
+	return nil
+}
+
+// Decode decodes revert data, i.e. the 4 byte selector of an error followed by its ABI encoded arguments. It returns
+// nil if the selector is not registered or the arguments do not match it.
+func (r *RevertRegistry) Decode(data []byte) *DecodedRevert {
+	if len(data) < 4 {
+		return nil
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	r.mu.RLock()
+	e, ok := r.errors[selector]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	values, err := e.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil
+	}
+	decoded := &DecodedRevert{Name: e.Name, Signature: e.Sig, Args: make([]RevertArg, len(values))}
+	for i, v := range values {
+		decoded.Args[i] = RevertArg{Name: e.Inputs[i].Name, Value: v}
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		decoded.Reason = reason
+	}
+	return decoded
+}
+
+// DecodeError decodes the revert data carried by an error returned from an RPC, such as the error of an eth_call or
+// eth_estimateGas which reverted.
+func (r *RevertRegistry) DecodeError(err error) *DecodedRevert {
+	if err == nil {
+		return nil
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := revertData(dataErr.ErrorData()); ok {
+			return r.Decode(data)
+		}
+	}
+	if jErr := ExtractRPCErrorOrNil(err); jErr != nil {
+		if data, ok := revertData(jErr.Data); ok {
+			return r.Decode(data)
+		}
+	}
+	return nil
+}
+
+// revertData returns the revert data of the data field of a JSON-RPC error. Geth returns the hex encoded revert data,
+// Parity prefixes it with "Reverted ", and some RPCs nest it in an object.
+func revertData(data interface{}) ([]byte, bool) {
+	switch data := data.(type) {
+	case []byte:
+		return data, len(data) > 0
+	case string:
+		data = strings.TrimPrefix(data, "Reverted ")
+		if !strings.HasPrefix(data, "0x") {
+			return nil, false
+		}
+		b, err := hex.DecodeString(data[2:])
+		return b, err == nil && len(b) > 0
+	case map[string]interface{}:
+		return revertData(data["data"])
+	default:
+		return nil, false
+	}
+}
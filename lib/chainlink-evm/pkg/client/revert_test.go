@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+)
+
+const healthFactorABI = `[{"type":"error","name":"HealthFactorTooLow","inputs":[{"name":"account","type":"address"},{"name":"healthFactor","type":"uint256"},{"name":"positionId","type":"bytes32"}]}]`
+
+func TestRevertRegistry(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := abi.JSON(strings.NewReader(healthFactorABI))
+	require.NoError(t, err)
+	account := common.HexToAddress("0x0000000000000000000000000000000000000abc")
+	customErr := parsed.Errors["HealthFactorTooLow"]
+	args, err := customErr.Inputs.Pack(account, big.NewInt(99), [32]byte{1})
+	require.NoError(t, err)
+	customData := append(customErr.ID[:4:4], args...)
+
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+	panicCode, err := abi.Arguments{{Type: uint256Type}}.Pack(big.NewInt(0x11))
+	require.NoError(t, err)
+	panicData := append(hexutil.MustDecode("0x4e487b71"), panicCode...)
+
+	t.Run("Error and Panic are always registered", func(t *testing.T) {
+		registry := client.NewRevertRegistry()
+
+		revert := registry.Decode(hexutil.MustDecode(revertBoom))
+		require.NotNil(t, revert)
+		assert.Equal(t, "Error", revert.Name)
+		assert.Equal(t, "Error(string)", revert.Signature)
+		assert.Equal(t, "boom", revert.Reason)
+		assert.Equal(t, `Error("boom")`, revert.String())
+
+		revert = registry.Decode(panicData)
+		require.NotNil(t, revert)
+		assert.Equal(t, "Panic", revert.Name)
+		assert.Equal(t, "Panic(17): arithmetic underflow or overflow", revert.String())
+	})
+
+	t.Run("custom errors", func(t *testing.T) {
+		registry := client.NewRevertRegistry()
+		assert.Nil(t, registry.Decode(customData))
+
+		require.NoError(t, registry.RegisterJSON(healthFactorABI))
+		revert := registry.Decode(customData)
+		require.NotNil(t, revert)
+		assert.Equal(t, "HealthFactorTooLow(address,uint256,bytes32)", revert.Signature)
+		assert.Empty(t, revert.Reason)
+		require.Len(t, revert.Args, 3)
+		assert.Equal(t, client.RevertArg{Name: "account", Value: account}, revert.Args[0])
+		assert.Equal(t, "HealthFactorTooLow(account="+account.String()+", healthFactor=99, positionId=0x"+
+			"01"+strings.Repeat("00", 31)+")", revert.String())
+
+		// arguments which don't match the error's inputs are not decoded
+		assert.Nil(t, registry.Decode(customData[:36]))
+		require.Error(t, registry.RegisterJSON("not an abi"))
+
+		// registering the same error again is fine
+		require.NoError(t, registry.RegisterJSON(healthFactorABI))
+	})
+
+	t.Run("selector collisions", func(t *testing.T) {
+		registry := client.NewRevertRegistry()
+		require.NoError(t, registry.RegisterJSON(`[{"type":"error","name":"burn","inputs":[{"name":"amount","type":"uint256"}]}]`))
+
+		// collate_propagate_storage(bytes16) has the same selector as burn(uint256)
+		err := registry.RegisterJSON(`[{"type":"error","name":"collate_propagate_storage","inputs":[{"name":"","type":"bytes16"}]},` +
+			strings.TrimPrefix(healthFactorABI, "["))
+		require.ErrorContains(t, err, "selector 0x42966c68 of collate_propagate_storage(bytes16) is already registered for burn(uint256)")
+
+		amount, err := abi.Arguments{{Type: uint256Type}}.Pack(big.NewInt(7))
+		require.NoError(t, err)
+		revert := registry.Decode(append(hexutil.MustDecode("0x42966c68"), amount...))
+		require.NotNil(t, revert)
+		assert.Equal(t, "burn(uint256)", revert.Signature)
+		// the other errors of the ABI are still registered
+		assert.NotNil(t, registry.Decode(customData))
+	})
+
+	t.Run("errors returned by RPCs", func(t *testing.T) {
+		registry := client.NewRevertRegistry()
+		require.NoError(t, registry.RegisterJSON(healthFactorABI))
+
+		// geth
+		revert := registry.DecodeError(pkgerrors.Wrap(client.JsonError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(customData)}, "call failed"))
+		require.NotNil(t, revert)
+		assert.Equal(t, "HealthFactorTooLow", revert.Name)
+
+		// parity
+		revert = registry.DecodeError(client.JsonError{Code: -32015, Message: "VM execution error.", Data: "Reverted " + hexutil.Encode(customData)})
+		require.NotNil(t, revert)
+		assert.Equal(t, "HealthFactorTooLow", revert.Name)
+
+		assert.Nil(t, registry.DecodeError(client.JsonError{Code: 3, Message: "execution reverted"}))
+		assert.Nil(t, registry.DecodeError(pkgerrors.New("execution reverted")))
+		assert.Nil(t, registry.DecodeError(nil))
+	})
+
+	t.Run("JsonError and SendError include the decoded revert", func(t *testing.T) {
+		jErr := client.JsonError{Code: 3, Message: "execution reverted: boom", Data: revertBoom}
+		assert.Equal(t, "json-rpc error { Code = 3, Message = 'execution reverted: boom', Data = '"+revertBoom+`', Revert = 'Error("boom")' }`, jErr.String())
+		require.NotNil(t, jErr.Revert())
+
+		sendErr := client.NewSendError(jErr)
+		require.NotNil(t, sendErr.Revert())
+		assert.Equal(t, "boom", sendErr.Revert().Reason)
+		assert.Nil(t, client.NewSendErrorS("nonce too low").Revert())
+	})
+}
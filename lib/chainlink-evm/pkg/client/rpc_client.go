@@ -81,6 +81,10 @@ var (
 
 const rpcSubscriptionMethodNewHeads = "newHeads"
 
+// maxFilterLogsSplitDepth caps how many times FilterLogs halves a block range rejected by the RPC, and so the number of
+// calls a single query can make, to at most 2^(maxFilterLogsSplitDepth+1)-1.
+const maxFilterLogsSplitDepth = 8
+
 type rawclient struct {
 	rpc  *rpc.Client
 	geth *ethclient.Client
@@ -100,6 +104,10 @@ type RPCClient struct {
 	rpcTimeout                 time.Duration
 	chainType                  chaintype.ChainType
 	clientErrors               config.ClientErrors
+	headerVerifier             *headerVerifier // nil unless header verification is enabled for this node
+	responseCache              *responseCache  // nil unless the response cache is enabled for this chain
+	rateLimiter                *rateLimiter    // nil unless rate limits are configured for this node
+	parityTraces               atomic.Bool     // set once the RPC turned out to only serve the Parity trace dialect
 
 	ws   atomic.Pointer[rawclient]
 	http atomic.Pointer[rawclient]
@@ -208,7 +216,12 @@ func (r *RPCClient) DialHTTP() error {
 	lggr.Debugw("RPC dial: evmclient.Client#dial")
 
 	var httprpc *rpc.Client
-	httprpc, err := rpc.DialHTTP(http.uri.String())
+	var err error
+	if r.rateLimiter != nil {
+		httprpc, err = rpc.DialHTTPWithClient(http.uri.String(), r.rateLimiter.httpClient())
+	} else {
+		httprpc, err = rpc.DialHTTP(http.uri.String())
+	}
 	if err != nil {
 		promEVMPoolRPCNodeDialsFailed.WithLabelValues(r.chainID.String(), r.name).Inc()
 		return r.wrapRPCClientError(pkgerrors.Wrapf(err, "error while dialing HTTP: %v", http.uri.Redacted()))
@@ -260,9 +273,13 @@ func (r *RPCClient) logResult(
 		logger.Sugared(lggr).Tracew(fmt.Sprintf("evmclient.Client#%s RPC call success", callName), results...)
 	} else {
 		promEVMPoolRPCNodeCallsFailed.WithLabelValues(r.chainID.String(), r.name).Inc()
+		results = append(results, "err", err)
+		if revert := DecodeRevert(err); revert != nil {
+			results = append(results, "revert", revert)
+		}
 		lggr.Debugw(
 			fmt.Sprintf("evmclient.Client#%s RPC call failure", callName),
-			append(results, "err", err)...,
+			results...,
 		)
 	}
 
@@ -441,6 +458,10 @@ func (r *RPCClient) SubscribeToHeads(ctx context.Context) (ch <-chan *evmtypes.H
 	channel := make(chan *evmtypes.Head)
 	forwarder := newSubForwarder(channel, func(head *evmtypes.Head) (*evmtypes.Head, error) {
 		head.EVMChainID = ubig.New(r.chainID)
+		if err := r.verifyHead(head); err != nil {
+			return nil, err
+		}
+		r.responseCache.observeHead(head)
 		r.OnNewHead(ctx, chStopInFlight, head)
 		return head, nil
 	}, r.wrapRPCClientError)
@@ -577,7 +598,9 @@ func (r *RPCClient) LatestSafeBlock(ctx context.Context) (head *evmtypes.Head, e
 	}
 
 	head.EVMChainID = ubig.New(r.chainID)
-
+	if err = r.verifyHead(head); err != nil {
+		return nil, err
+	}
 	return
 }
 
@@ -605,6 +628,10 @@ func (r *RPCClient) latestFinalizedBlock(ctx context.Context) (head *evmtypes.He
 		return
 	}
 	head.EVMChainID = ubig.New(r.chainID)
+	if err = r.verifyHead(head); err != nil {
+		return nil, err
+	}
+	r.responseCache.observeFinalized(head)
 	return
 }
 
@@ -650,14 +677,35 @@ func (r *RPCClient) BlockByNumber(ctx context.Context, number *big.Int) (head *e
 	}
 
 	head.EVMChainID = ubig.New(r.chainID)
+	if err = r.verifyHead(head); err != nil {
+		return nil, err
+	}
 
 	if hexNumber == rpc.LatestBlockNumber.String() {
+		r.responseCache.observeHead(head)
 		r.OnNewHead(ctx, chStopInFlight, head)
 	}
 
 	return
 }
 
+// pinBlock returns the head of the block with the given number, which may be nil for the latest block or a negative
+// block tag, so that a read can be repeated at exactly the same block on other nodes.
+func (r *RPCClient) pinBlock(ctx context.Context, blockNumber *big.Int) (head *evmtypes.Head, err error) {
+	err = r.ethGetBlockByNumber(ctx, ToBackwardCompatibleBlockNumArg(blockNumber), &head)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, r.wrapRPCClientError(ethereum.NotFound)
+	}
+	head.EVMChainID = ubig.New(r.chainID)
+	if err = r.verifyHead(head); err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
 func (r *RPCClient) ethGetBlockByNumber(ctx context.Context, number string, result interface{}) (err error) {
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
@@ -691,9 +739,24 @@ func (r *RPCClient) BlockByHash(ctx context.Context, hash common.Hash) (head *ev
 		return
 	}
 	head.EVMChainID = ubig.New(r.chainID)
+	if err = r.verifyHead(head); err != nil {
+		return nil, err
+	}
 	return
 }
 
+// verifyHead rejects heads which fail header verification, if it is enabled for this node.
+func (r *RPCClient) verifyHead(head *evmtypes.Head) error {
+	if r.headerVerifier == nil {
+		return nil
+	}
+	if err := r.headerVerifier.verify(head); err != nil {
+		r.rpcLog.Warnw("Head failed header verification", "err", err)
+		return r.wrapRPCClientError(err)
+	}
+	return nil
+}
+
 func (r *RPCClient) BlockByHashGeth(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
@@ -977,6 +1040,35 @@ func (r *RPCClient) CallContract(ctx context.Context, msg interface{}, blockNumb
 	return
 }
 
+// CallContractAtHash executes a message call at the block with the given hash.
+func (r *RPCClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) (val []byte, err error) {
+	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.largePayloadRPCTimeout)
+	defer cancel()
+	lggr := r.newRqLggr().With("callMsg", msg, "blockHash", blockHash)
+
+	lggr.Debug("RPC call: evmclient.Client#CallContractAtHash")
+	start := time.Now()
+	var hex hexutil.Bytes
+	blockArg := rpc.BlockNumberOrHashWithHash(blockHash, false)
+	if http != nil {
+		err = http.rpc.CallContext(ctx, &hex, "eth_call", r.prepareCallArgs(msg), blockArg)
+		err = r.wrapHTTP(err)
+	} else {
+		err = ws.rpc.CallContext(ctx, &hex, "eth_call", r.prepareCallArgs(msg), blockArg)
+		err = r.wrapWS(err)
+	}
+	if err == nil {
+		val = hex
+	}
+	duration := time.Since(start)
+
+	r.logResult(lggr, err, duration, r.getRPCDomain(), "CallContractAtHash",
+		"val", val,
+	)
+
+	return
+}
+
 func (r *RPCClient) PendingCallContract(ctx context.Context, msg interface{}) (val []byte, err error) {
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.largePayloadRPCTimeout)
 	defer cancel()
@@ -1057,6 +1149,30 @@ func (r *RPCClient) BalanceAt(ctx context.Context, account common.Address, block
 	return
 }
 
+// BalanceAtHash returns the balance of account at the block with the given hash.
+func (r *RPCClient) BalanceAtHash(ctx context.Context, account common.Address, blockHash common.Hash) (balance *big.Int, err error) {
+	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
+	defer cancel()
+	lggr := r.newRqLggr().With("account", account.Hex(), "blockHash", blockHash)
+
+	lggr.Debug("RPC call: evmclient.Client#BalanceAtHash")
+	start := time.Now()
+	if http != nil {
+		balance, err = http.geth.BalanceAtHash(ctx, account, blockHash)
+		err = r.wrapHTTP(err)
+	} else {
+		balance, err = ws.geth.BalanceAtHash(ctx, account, blockHash)
+		err = r.wrapWS(err)
+	}
+	duration := time.Since(start)
+
+	r.logResult(lggr, err, duration, r.getRPCDomain(), "BalanceAtHash",
+		"balance", balance,
+	)
+
+	return
+}
+
 func (r *RPCClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (feeHistory *ethereum.FeeHistory, err error) {
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
@@ -1122,7 +1238,42 @@ func (r *RPCClient) FilterEvents(ctx context.Context, q ethereum.FilterQuery) ([
 	return r.FilterLogs(ctx, q)
 }
 
-func (r *RPCClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) (l []types.Log, err error) {
+// FilterLogs returns the logs matching q. If the RPC rejects the query because its block range or result set exceeds a
+// provider limit, the block range is bisected until the RPC accepts the parts, and their logs are merged.
+func (r *RPCClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return r.filterLogsSplit(ctx, q, 0)
+}
+
+// filterLogsSplit retries the halves of a block range rejected by the RPC, up to maxFilterLogsSplitDepth times.
+func (r *RPCClient) filterLogsSplit(ctx context.Context, q ethereum.FilterQuery, depth int) ([]types.Log, error) {
+	l, err := r.filterLogs(ctx, q)
+	// timeouts aren't split, since they may be caused by the node rather than the size of the range
+	if err == nil || !IsTooManyResults(err, r.clientErrors) || isTimeout(err) {
+		return l, err
+	}
+	from, to := q.FromBlock, q.ToBlock
+	if q.BlockHash != nil || from == nil || to == nil || from.Sign() < 0 || from.Cmp(to) >= 0 || ctx.Err() != nil ||
+		depth >= maxFilterLogsSplitDepth {
+		return nil, err
+	}
+
+	mid := new(big.Int).Rsh(new(big.Int).Add(from, to), 1)
+	r.rpcLog.Debugw("eth_getLogs exceeded a provider limit, splitting the block range", "err", err, "from", from, "to", to)
+	lower, upper := q, q
+	lower.ToBlock = mid
+	upper.FromBlock = new(big.Int).Add(mid, big.NewInt(1))
+	lowerLogs, err := r.filterLogsSplit(ctx, lower, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	upperLogs, err := r.filterLogsSplit(ctx, upper, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return append(lowerLogs, upperLogs...), nil
+}
+
+func (r *RPCClient) filterLogs(ctx context.Context, q ethereum.FilterQuery) (l []types.Log, err error) {
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
 	lggr := r.newRqLggr().With("q", q)
@@ -1216,6 +1367,47 @@ func (r *RPCClient) ChainID(ctx context.Context) (chainID *big.Int, err error) {
 	return
 }
 
+// TraceTransaction returns the call trace of the mined transaction with the given hash.
+func (r *RPCClient) TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error) {
+	return withTraceDialect(r, func(dialect TraceDialect) (*CallFrame, error) {
+		return traceTransaction(ctx, r, dialect, txHash)
+	})
+}
+
+// TraceCall returns the call trace of msg, executed on top of the state of the given block.
+func (r *RPCClient) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error) {
+	return withTraceDialect(r, func(dialect TraceDialect) (*CallFrame, error) {
+		return traceCall(ctx, r, dialect, r.prepareCallArgs(msg), blockNumber)
+	})
+}
+
+// TraceTransactionPrestate returns the state of the accounts touched by the mined transaction with the given hash,
+// before it was executed.
+func (r *RPCClient) TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (PrestateTrace, error) {
+	return withTraceDialect(r, func(dialect TraceDialect) (PrestateTrace, error) {
+		return traceTransactionPrestate(ctx, r, dialect, txHash)
+	})
+}
+
+// withTraceDialect traces with the Geth dialect, unless the RPC does not serve it, in which case it falls back to the
+// Parity dialect and keeps using it for subsequent traces.
+func withTraceDialect[T any](r *RPCClient, trace func(TraceDialect) (T, error)) (T, error) {
+	if r.parityTraces.Load() {
+		return trace(TraceDialectParity)
+	}
+	result, err := trace(TraceDialectGeth)
+	if err == nil || !isMethodNotFound(err) {
+		return result, err
+	}
+	parityResult, parityErr := trace(TraceDialectParity)
+	if parityErr != nil && isMethodNotFound(parityErr) {
+		return result, err
+	}
+	r.rpcLog.Infow("RPC does not serve the Geth trace dialect, using the Parity dialect", "err", err)
+	r.parityTraces.Store(true)
+	return parityResult, parityErr
+}
+
 // newRqLggr generates a new logger with a unique request ID
 func (r *RPCClient) newRqLggr() logger.SugaredLogger {
 	return r.rpcLog.With("requestID", uuid.New())
@@ -15,6 +15,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
@@ -548,6 +549,87 @@ func TestRPCClientFilterLogs(t *testing.T) {
 		})
 
 	})
+	t.Run("Splits block ranges rejected by the RPC", func(t *testing.T) {
+		const maxRange = 3
+		var requests atomic.Int32
+		server := testutils.NewWSServer(t, chainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			if method != "eth_getLogs" {
+				return
+			}
+			requests.Add(1)
+			if params.Get("0.blockHash").Exists() {
+				resp.Error.Code = -32005
+				resp.Error.Message = "query returned more than 10000 results"
+				return
+			}
+			from, err := hexutil.DecodeUint64(params.Get("0.fromBlock").String())
+			require.NoError(t, err)
+			to, err := hexutil.DecodeUint64(params.Get("0.toBlock").String())
+			require.NoError(t, err)
+			if to-from+1 > maxRange {
+				resp.Error.Code = -32005
+				resp.Error.Message = "query returned more than 10000 results"
+				return
+			}
+			var logs []types.Log
+			for block := from; block <= to; block++ {
+				logs = append(logs, types.Log{BlockNumber: block, Topics: []common.Hash{{}}})
+			}
+			raw, err := json.Marshal(logs)
+			require.NoError(t, err)
+			resp.Result = string(raw)
+			return
+		})
+		rpc := client.NewRPCClient(nodePoolCfg, lggr, server.WSURL(), nil, "rpc", 1, chainID, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+		defer rpc.Close()
+		require.NoError(t, rpc.Dial(ctx))
+
+		logs, err := rpc.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(10)})
+		require.NoError(t, err)
+		require.Len(t, logs, 10)
+		for i, log := range logs {
+			assert.Equal(t, uint64(i+1), log.BlockNumber)
+		}
+		// [1,10] -> [1,5] -> [1,3] [4,5]; [6,10] -> [6,8] [9,10]
+		assert.Equal(t, int32(7), requests.Load())
+
+		t.Run("unless the query is for a block hash", func(t *testing.T) {
+			requests.Store(0)
+			blockHash := common.Hash{1}
+			_, err := rpc.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &blockHash})
+			require.Error(t, err)
+			assert.Equal(t, int32(1), requests.Load())
+		})
+	})
+	rejectingRPC := func(t *testing.T, code int, message string) (*client.RPCClient, *atomic.Int32) {
+		var requests atomic.Int32
+		server := testutils.NewWSServer(t, chainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			if method != "eth_getLogs" {
+				return
+			}
+			requests.Add(1)
+			resp.Error.Code = code
+			resp.Error.Message = message
+			return
+		})
+		rpc := client.NewRPCClient(nodePoolCfg, lggr, server.WSURL(), nil, "rpc", 1, chainID, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+		t.Cleanup(rpc.Close)
+		require.NoError(t, rpc.Dial(ctx))
+		return rpc, &requests
+	}
+	t.Run("Doesn't split block ranges on RPC timeouts", func(t *testing.T) {
+		rpc, requests := rejectingRPC(t, -32002, "request timed out")
+		_, err := rpc.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(10)})
+		require.Error(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+	t.Run("Stops splitting block ranges after the max depth", func(t *testing.T) {
+		rpc, requests := rejectingRPC(t, -32005, "query returned more than 10000 results")
+		_, err := rpc.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(100_000)})
+		require.Error(t, err)
+		// the lower half fails first at each of the 8 splits
+		assert.Equal(t, int32(9), requests.Load())
+	})
 }
 
 func TestRPCClient_LatestFinalizedBlock(t *testing.T) {
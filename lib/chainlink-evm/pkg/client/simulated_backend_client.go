@@ -840,6 +840,21 @@ func (c *SimulatedBackendClient) ethGetLogs(ctx context.Context, result interfac
 	}
 }
 
+// TraceTransaction is not supported by the simulated backend.
+func (c *SimulatedBackendClient) TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error) {
+	return nil, errors.New("SimulatedBackendClient does not support tracing")
+}
+
+// TraceCall is not supported by the simulated backend.
+func (c *SimulatedBackendClient) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error) {
+	return nil, errors.New("SimulatedBackendClient does not support tracing")
+}
+
+// TraceTransactionPrestate is not supported by the simulated backend.
+func (c *SimulatedBackendClient) TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (PrestateTrace, error) {
+	return nil, errors.New("SimulatedBackendClient does not support tracing")
+}
+
 func (c *SimulatedBackendClient) CheckTxValidity(ctx context.Context, from common.Address, to common.Address, data []byte) *SendError {
 	return nil
 }
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/smartcontractkit/freeport"
+	"github.com/stretchr/testify/require"
+)
+
+// SimulatedRPCServer exposes a simulated chain over a local HTTP and websocket JSON-RPC endpoint, so that the real
+// RPCClient code paths (batching, subscriptions, error parsing) can be tested against it. The eth, net and web3
+// namespaces are served, including newHeads and logs subscriptions. Unlike a real node, blocks are only produced
+// when Commit is called.
+//
+// A simulated chain always uses chain ID 1337.
+type SimulatedRPCServer struct {
+	t       testing.TB
+	backend *simulated.Backend
+	httpURL *url.URL
+	wsURL   *url.URL
+}
+
+// NewSimulatedRPCServer starts a simulated chain with the given genesis allocation, which is closed at the end of the
+// test. Options are applied to the simulated backend, as with simulated.NewBackend.
+func NewSimulatedRPCServer(t testing.TB, alloc types.GenesisAlloc, options ...func(nodeConf *node.Config, ethConf *ethconfig.Config)) *SimulatedRPCServer {
+	port := freeport.GetOne(t)
+	host := "127.0.0.1"
+	modules := []string{"eth", "net", "web3"}
+	serveRPC := func(nodeConf *node.Config, _ *ethconfig.Config) {
+		// The websocket endpoint shares the HTTP server, since they listen on the same port.
+		nodeConf.HTTPHost, nodeConf.HTTPPort = host, port
+		nodeConf.WSHost, nodeConf.WSPort = host, port
+		nodeConf.HTTPModules, nodeConf.WSModules = modules, modules
+		nodeConf.HTTPVirtualHosts = []string{"*"}
+		nodeConf.WSOrigins = []string{"*"}
+	}
+
+	backend := simulated.NewBackend(alloc, append(options, serveRPC)...)
+	t.Cleanup(func() { require.NoError(t, backend.Close()) })
+
+	addr := host + ":" + strconv.Itoa(port)
+	return &SimulatedRPCServer{
+		t:       t,
+		backend: backend,
+		httpURL: &url.URL{Scheme: "http", Host: addr},
+		wsURL:   &url.URL{Scheme: "ws", Host: addr},
+	}
+}
+
+// HTTPURL returns the url of the HTTP JSON-RPC endpoint.
+func (s *SimulatedRPCServer) HTTPURL() *url.URL { return s.httpURL }
+
+// WSURL returns the url of the websocket JSON-RPC endpoint.
+func (s *SimulatedRPCServer) WSURL() *url.URL { return s.wsURL }
+
+// Backend returns the simulated backend, e.g. to deploy contracts with its Client.
+func (s *SimulatedRPCServer) Backend() *simulated.Backend { return s.backend }
+
+// ChainID returns the chain ID of the simulated chain.
+func (s *SimulatedRPCServer) ChainID() *big.Int {
+	return big.NewInt(1337)
+}
+
+// Commit mines a block with the pending transactions, notifying the newHeads and logs subscribers.
+func (s *SimulatedRPCServer) Commit() common.Hash {
+	return s.backend.Commit()
+}
+
+// Mine mines n blocks and returns the hash of the last one.
+func (s *SimulatedRPCServer) Mine(n int) (hash common.Hash) {
+	for i := 0; i < n; i++ {
+		hash = s.backend.Commit()
+	}
+	return hash
+}
+
+// Reorg replaces the latest depth blocks with a longer side chain of depth+1 empty blocks, returning the hash of its
+// head. Transactions of the replaced blocks are dropped.
+func (s *SimulatedRPCServer) Reorg(depth int) (common.Hash, error) {
+	ctx := s.t.Context()
+	latest, err := s.backend.Client().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if int64(depth) > latest.Number.Int64() {
+		return common.Hash{}, fmt.Errorf("cannot reorg %d blocks at height %d", depth, latest.Number)
+	}
+	ancestor, err := s.backend.Client().HeaderByNumber(ctx, new(big.Int).Sub(latest.Number, big.NewInt(int64(depth))))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get common ancestor: %w", err)
+	}
+	s.backend.Rollback()
+	if err = s.backend.Fork(ancestor.Hash()); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fork at block %d: %w", ancestor.Number, err)
+	}
+	return s.Mine(depth + 1), nil
+}
+
+// AdjustTime moves the timestamp of the chain forward by adjustment and mines a block. There must be no pending
+// transactions.
+func (s *SimulatedRPCServer) AdjustTime(adjustment time.Duration) error {
+	return s.backend.AdjustTime(adjustment)
+}
@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+func TestSimulatedRPCServer(t *testing.T) {
+	ctx := testutils.Context(t)
+	server := client.NewSimulatedRPCServer(t, types.GenesisAlloc{})
+
+	nodePoolCfg := client.TestNodePoolConfig{NodeFinalizedBlockPollInterval: time.Second}
+	rpcClient := client.NewRPCClient(nodePoolCfg, logger.Test(t), server.WSURL(), server.HTTPURL(), "sim", 1, server.ChainID(), multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+	t.Cleanup(rpcClient.Close)
+	require.NoError(t, rpcClient.Dial(ctx))
+
+	chainID, err := rpcClient.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, server.ChainID(), chainID)
+
+	heads, sub, err := rpcClient.SubscribeToHeads(ctx)
+	require.NoError(t, err)
+	t.Cleanup(sub.Unsubscribe)
+	awaitHead := func(number int64) *evmtypes.Head {
+		for {
+			select {
+			case head := <-heads:
+				if head.Number == number {
+					return head
+				}
+			case <-time.After(testutils.WaitTimeout(t)):
+				t.Fatalf("timed out waiting for head %d", number)
+				return nil
+			}
+		}
+	}
+
+	t.Run("mines blocks", func(t *testing.T) {
+		hash := server.Mine(2)
+		assert.Equal(t, hash, awaitHead(2).Hash)
+
+		var block1, block2 map[string]any
+		require.NoError(t, rpcClient.BatchCallContext(ctx, []rpc.BatchElem{
+			{Method: "eth_getBlockByNumber", Args: []any{"0x1", false}, Result: &block1},
+			{Method: "eth_getBlockByNumber", Args: []any{"0x2", false}, Result: &block2},
+		}))
+		assert.Equal(t, block1["hash"], block2["parentHash"])
+	})
+
+	t.Run("reorgs", func(t *testing.T) {
+		server.Mine(1)
+		replaced := awaitHead(3)
+
+		hash, err := server.Reorg(1)
+		require.NoError(t, err)
+		head := awaitHead(4)
+		assert.Equal(t, hash, head.Hash)
+
+		block3, err := rpcClient.BlockByNumber(ctx, big.NewInt(3))
+		require.NoError(t, err)
+		assert.NotEqual(t, replaced.Hash, block3.Hash)
+		assert.Equal(t, replaced.ParentHash, block3.ParentHash)
+	})
+
+	t.Run("adjusts time", func(t *testing.T) {
+		before, err := rpcClient.BlockByNumber(ctx, nil)
+		require.NoError(t, err)
+		require.NoError(t, server.AdjustTime(time.Hour))
+		after := awaitHead(before.Number + 1)
+		assert.GreaterOrEqual(t, after.Timestamp.Sub(before.Timestamp), time.Hour)
+	})
+
+	t.Run("classifies errors", func(t *testing.T) {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		to := testutils.NewAddress()
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(server.ChainID()), &types.LegacyTx{
+			To:       &to,
+			Gas:      21000,
+			GasPrice: big.NewInt(1e9),
+			Value:    big.NewInt(1),
+		})
+		require.NoError(t, err)
+		_, code, err := rpcClient.SendTransaction(ctx, tx)
+		require.Error(t, err)
+		assert.Equal(t, multinode.InsufficientFunds, code)
+	})
+}
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TraceDialect is the tracing API of an RPC.
+type TraceDialect string
+
+const (
+	// TraceDialectGeth is the debug_traceTransaction and debug_traceCall API of Geth and its forks, also served by Erigon
+	// and Nethermind.
+	TraceDialectGeth TraceDialect = "geth"
+	// TraceDialectParity is the trace_transaction, trace_call and trace_replayTransaction API of OpenEthereum, Erigon and
+	// Nethermind.
+	TraceDialectParity TraceDialect = "parity"
+)
+
+// CallFrame is a call made while executing a transaction, in the format of Geth's callTracer. Calls holds the internal
+// calls made by the frame, in execution order.
+type CallFrame struct {
+	// Type is CALL, STATICCALL, DELEGATECALL, CALLCODE, CREATE, CREATE2 or SELFDESTRUCT.
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+}
+
+// FailingCall returns the innermost call which caused f to fail, following the last failed internal call of each frame.
+// It returns nil if f succeeded.
+func (f *CallFrame) FailingCall() *CallFrame {
+	if f == nil || f.Error == "" {
+		return nil
+	}
+	for i := len(f.Calls) - 1; i >= 0; i-- {
+		if f.Calls[i].Error != "" {
+			return f.Calls[i].FailingCall()
+		}
+	}
+	return f
+}
+
+// PrestateAccount is the state of an account before a transaction was executed, in the format of Geth's
+// prestateTracer.
+type PrestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// PrestateTrace is the state of every account touched by a transaction, before it was executed.
+type PrestateTrace map[common.Address]*PrestateAccount
+
+// traceClient is the subset of the Client used to fetch traces.
+type traceClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+var (
+	gethCallTracer     = map[string]string{"tracer": "callTracer"}
+	gethPrestateTracer = map[string]string{"tracer": "prestateTracer"}
+)
+
+// traceTransaction returns the call trace of the mined transaction with the given hash.
+func traceTransaction(ctx context.Context, c traceClient, dialect TraceDialect, txHash common.Hash) (*CallFrame, error) {
+	if dialect == TraceDialectParity {
+		var traces []parityTrace
+		if err := c.CallContext(ctx, &traces, "trace_transaction", txHash); err != nil {
+			return nil, err
+		}
+		return parityCallFrame(traces)
+	}
+	var frame *CallFrame
+	if err := c.CallContext(ctx, &frame, "debug_traceTransaction", txHash, gethCallTracer); err != nil {
+		return nil, err
+	}
+	if frame == nil {
+		return nil, ethereum.NotFound
+	}
+	return frame, nil
+}
+
+// traceCall returns the call trace of msg, executed on top of the state of the given block. A nil blockNumber implies
+// the latest block.
+func traceCall(ctx context.Context, c traceClient, dialect TraceDialect, callArg interface{}, blockNumber *big.Int) (*CallFrame, error) {
+	blockArg := ToBackwardCompatibleBlockNumArg(blockNumber)
+	if dialect == TraceDialectParity {
+		var result struct {
+			Trace []parityTrace `json:"trace"`
+		}
+		if err := c.CallContext(ctx, &result, "trace_call", callArg, []string{"trace"}, blockArg); err != nil {
+			return nil, err
+		}
+		return parityCallFrame(result.Trace)
+	}
+	var frame *CallFrame
+	if err := c.CallContext(ctx, &frame, "debug_traceCall", callArg, blockArg, gethCallTracer); err != nil {
+		return nil, err
+	}
+	if frame == nil {
+		return nil, ethereum.NotFound
+	}
+	return frame, nil
+}
+
+// traceTransactionPrestate returns the prestate trace of the mined transaction with the given hash. The Parity dialect
+// only reports the prior values of the fields the transaction changed, so the fields it left unchanged are unset.
+func traceTransactionPrestate(ctx context.Context, c traceClient, dialect TraceDialect, txHash common.Hash) (PrestateTrace, error) {
+	if dialect == TraceDialectParity {
+		var result struct {
+			StateDiff map[common.Address]parityAccountDiff `json:"stateDiff"`
+		}
+		if err := c.CallContext(ctx, &result, "trace_replayTransaction", txHash, []string{"stateDiff"}); err != nil {
+			return nil, err
+		}
+		if result.StateDiff == nil {
+			return nil, ethereum.NotFound
+		}
+		prestate := make(PrestateTrace, len(result.StateDiff))
+		for address, diff := range result.StateDiff {
+			prestate[address] = diff.prestate()
+		}
+		return prestate, nil
+	}
+	var prestate PrestateTrace
+	if err := c.CallContext(ctx, &prestate, "debug_traceTransaction", txHash, gethPrestateTracer); err != nil {
+		return nil, err
+	}
+	if prestate == nil {
+		return nil, ethereum.NotFound
+	}
+	return prestate, nil
+}
+
+var methodNotFoundRegex = regexp.MustCompile(`(?i)(method .*(does not exist|is not available|not found|not supported)|unsupported method|namespace .* is disabled)`)
+
+// isMethodNotFound returns true if the RPC does not serve the called method.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.ErrorCode() == -32601 || methodNotFoundRegex.MatchString(rpcErr.Error())
+}
+
+// parityTrace is an element of the flat list of calls returned by the Parity dialect, in execution order.
+type parityTrace struct {
+	Action struct {
+		CallType       string          `json:"callType"`
+		CreationMethod string          `json:"creationMethod"`
+		From           common.Address  `json:"from"`
+		To             *common.Address `json:"to"`
+		Gas            hexutil.Uint64  `json:"gas"`
+		Input          hexutil.Bytes   `json:"input"`
+		Init           hexutil.Bytes   `json:"init"`
+		Value          *hexutil.Big    `json:"value"`
+		Address        common.Address  `json:"address"`
+		RefundAddress  *common.Address `json:"refundAddress"`
+		Balance        *hexutil.Big    `json:"balance"`
+	} `json:"action"`
+	Result *struct {
+		GasUsed hexutil.Uint64  `json:"gasUsed"`
+		Output  hexutil.Bytes   `json:"output"`
+		Address *common.Address `json:"address"`
+		Code    hexutil.Bytes   `json:"code"`
+	} `json:"result"`
+	Error        string `json:"error"`
+	TraceAddress []int  `json:"traceAddress"`
+	Type         string `json:"type"`
+}
+
+func (t *parityTrace) callFrame() CallFrame {
+	f := CallFrame{
+		From:  t.Action.From,
+		Gas:   t.Action.Gas,
+		Value: t.Action.Value,
+		Error: t.Error,
+	}
+	switch t.Type {
+	case "create":
+		f.Type = "CREATE"
+		if t.Action.CreationMethod != "" {
+			f.Type = strings.ToUpper(t.Action.CreationMethod)
+		}
+		f.Input = t.Action.Init
+		if t.Result != nil {
+			f.To = t.Result.Address
+			f.GasUsed = t.Result.GasUsed
+			f.Output = t.Result.Code
+		}
+	case "suicide":
+		f.Type = "SELFDESTRUCT"
+		f.From = t.Action.Address
+		f.To = t.Action.RefundAddress
+		f.Value = t.Action.Balance
+	default:
+		f.Type = strings.ToUpper(t.Action.CallType)
+		f.To = t.Action.To
+		f.Input = t.Action.Input
+		if t.Result != nil {
+			f.GasUsed = t.Result.GasUsed
+			f.Output = t.Result.Output
+		}
+	}
+	if f.Error != "" && len(f.Output) > 0 {
+		if reason, err := abi.UnpackRevert(f.Output); err == nil {
+			f.RevertReason = reason
+		}
+	}
+	return f
+}
+
+// parityCallFrame nests the flat list of Parity traces by their trace addresses, which index the internal calls of
+// each frame starting from the root.
+func parityCallFrame(traces []parityTrace) (*CallFrame, error) {
+	if len(traces) == 0 {
+		return nil, ethereum.NotFound
+	}
+	if len(traces[0].TraceAddress) != 0 {
+		return nil, fmt.Errorf("first trace is not the root call: trace address %v", traces[0].TraceAddress)
+	}
+	root := traces[0].callFrame()
+	for _, t := range traces[1:] {
+		if t.Type == "reward" {
+			continue
+		}
+		if len(t.TraceAddress) == 0 {
+			return nil, errors.New("multiple root calls")
+		}
+		parent := &root
+		last := len(t.TraceAddress) - 1
+		for _, index := range t.TraceAddress[:last] {
+			if index >= len(parent.Calls) {
+				return nil, fmt.Errorf("trace address %v has no parent", t.TraceAddress)
+			}
+			parent = &parent.Calls[index]
+		}
+		if t.TraceAddress[last] != len(parent.Calls) {
+			return nil, fmt.Errorf("trace address %v is out of order", t.TraceAddress)
+		}
+		parent.Calls = append(parent.Calls, t.callFrame())
+	}
+	return &root, nil
+}
+
+// parityAccountDiff is the change to an account made by a transaction, as returned by the Parity dialect.
+type parityAccountDiff struct {
+	Balance parityDiff[hexutil.Big]                 `json:"balance"`
+	Nonce   parityDiff[hexutil.Uint64]              `json:"nonce"`
+	Code    parityDiff[hexutil.Bytes]               `json:"code"`
+	Storage map[common.Hash]parityDiff[common.Hash] `json:"storage"`
+}
+
+func (d *parityAccountDiff) prestate() *PrestateAccount {
+	var account PrestateAccount
+	if d.Balance.From != nil {
+		account.Balance = d.Balance.From
+	}
+	if d.Nonce.From != nil {
+		account.Nonce = uint64(*d.Nonce.From)
+	}
+	if d.Code.From != nil {
+		account.Code = *d.Code.From
+	}
+	for slot, diff := range d.Storage {
+		if diff.From == nil {
+			continue
+		}
+		if account.Storage == nil {
+			account.Storage = make(map[common.Hash]common.Hash)
+		}
+		account.Storage[slot] = *diff.From
+	}
+	return &account
+}
+
+// parityDiff is the change to a single field. From is the value before the transaction, and is nil if the field was
+// unchanged ("=") or did not exist ({"+": value}).
+type parityDiff[T any] struct {
+	From *T
+}
+
+func (d *parityDiff[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == `"="` {
+		return nil
+	}
+	var diff struct {
+		Removed *T `json:"-,"`
+		Changed *struct {
+			From *T `json:"from"`
+		} `json:"*"`
+	}
+	if err := json.Unmarshal(b, &diff); err != nil {
+		return err
+	}
+	switch {
+	case diff.Removed != nil:
+		d.From = diff.Removed
+	case diff.Changed != nil:
+		d.From = diff.Changed.From
+	}
+	return nil
+}
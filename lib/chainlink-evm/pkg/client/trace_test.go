@@ -0,0 +1,150 @@
+package client_test
+
+import (
+	"math/big"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+// revertBoom is the ABI encoding of Error("boom").
+const revertBoom = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000004" +
+	"626f6f6d00000000000000000000000000000000000000000000000000000000"
+
+func TestRPCClient_Trace(t *testing.T) {
+	t.Parallel()
+
+	txHash := common.HexToHash("0x5e3d6f0d8c2e2a4f0c7c1f0a5b8c9d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c")
+	newRPCClient := func(t *testing.T, handler testutils.JSONRPCHandler) *client.RPCClient {
+		server := testutils.NewHTTPServer(t, nil, handler)
+		httpURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		r := client.NewRPCClient(client.TestNodePoolConfig{}, logger.Test(t), nil, httpURL, "rpc", 1, testutils.FixtureChainID, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+		require.NoError(t, r.DialHTTP())
+		t.Cleanup(r.Close)
+		return r
+	}
+
+	t.Run("Geth dialect", func(t *testing.T) {
+		r := newRPCClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "debug_traceTransaction":
+				switch params.Get("1.tracer").String() {
+				case "callTracer":
+					resp.Result = `{"type":"CALL","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","gas":"0x5208","gasUsed":"0x5000","input":"0x","error":"execution reverted","calls":[
+						{"type":"STATICCALL","from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000003","gas":"0x100","gasUsed":"0x10","input":"0x"},
+						{"type":"DELEGATECALL","from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000004","gas":"0x100","gasUsed":"0x10","input":"0x","error":"execution reverted","revertReason":"boom"}]}`
+				case "prestateTracer":
+					resp.Result = `{"0x0000000000000000000000000000000000000001":{"balance":"0x10","nonce":3,"storage":{"0x0000000000000000000000000000000000000000000000000000000000000001":"0x0000000000000000000000000000000000000000000000000000000000000002"}}}`
+				}
+			case "debug_traceCall":
+				assert.Equal(t, "0x1", params.Get("1").String())
+				resp.Result = `{"type":"CALL","from":"0x0000000000000000000000000000000000000001","gas":"0x5208","gasUsed":"0x5000","input":"0x01"}`
+			}
+			return
+		})
+		ctx := tests.Context(t)
+
+		frame, err := r.TraceTransaction(ctx, txHash)
+		require.NoError(t, err)
+		assert.Equal(t, "CALL", frame.Type)
+		require.Len(t, frame.Calls, 2)
+		failing := frame.FailingCall()
+		require.NotNil(t, failing)
+		assert.Equal(t, "DELEGATECALL", failing.Type)
+		assert.Equal(t, "boom", failing.RevertReason)
+		assert.Nil(t, frame.Calls[0].FailingCall())
+
+		frame, err = r.TraceCall(ctx, ethereum.CallMsg{Data: []byte{1}}, big.NewInt(1))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1}, []byte(frame.Input))
+
+		prestate, err := r.TraceTransactionPrestate(ctx, txHash)
+		require.NoError(t, err)
+		account := prestate[common.HexToAddress("0x1")]
+		require.NotNil(t, account)
+		assert.Equal(t, big.NewInt(0x10), account.Balance.ToInt())
+		assert.Equal(t, uint64(3), account.Nonce)
+		assert.Equal(t, common.HexToHash("0x2"), account.Storage[common.HexToHash("0x1")])
+	})
+
+	t.Run("falls back to the Parity dialect", func(t *testing.T) {
+		var debugCalls atomic.Int32
+		r := newRPCClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			if strings.HasPrefix(method, "debug_") {
+				debugCalls.Add(1)
+				resp.Error.Code = -32601
+				resp.Error.Message = "the method " + method + " does not exist/is not available"
+				return
+			}
+			switch method {
+			case "trace_transaction":
+				resp.Result = `[
+					{"action":{"callType":"call","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","gas":"0x5208","input":"0x","value":"0x0"},"error":"Reverted","result":null,"subtraces":2,"traceAddress":[],"type":"call"},
+					{"action":{"from":"0x0000000000000000000000000000000000000002","gas":"0x100","init":"0x6000","value":"0x0"},"result":{"address":"0x0000000000000000000000000000000000000005","code":"0x00","gasUsed":"0x10"},"subtraces":1,"traceAddress":[0],"type":"create"},
+					{"action":{"address":"0x0000000000000000000000000000000000000005","refundAddress":"0x0000000000000000000000000000000000000002","balance":"0x1"},"result":null,"subtraces":0,"traceAddress":[0,0],"type":"suicide"},
+					{"action":{"callType":"delegatecall","from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000004","gas":"0x100","input":"0x"},"error":"Reverted","result":{"gasUsed":"0x10","output":"` + revertBoom + `"},"subtraces":0,"traceAddress":[1],"type":"call"}]`
+			case "trace_replayTransaction":
+				resp.Result = `{"output":"0x","stateDiff":{
+					"0x0000000000000000000000000000000000000001":{"balance":{"*":{"from":"0x10","to":"0x8"}},"nonce":{"*":{"from":"0x3","to":"0x4"}},"code":"=","storage":{
+						"0x0000000000000000000000000000000000000000000000000000000000000001":{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000000000000000000000000000003"}}}},
+					"0x0000000000000000000000000000000000000005":{"balance":{"+":"0x1"},"nonce":{"+":"0x1"},"code":{"+":"0x00"},"storage":{}}}}`
+			}
+			return
+		})
+		ctx := tests.Context(t)
+
+		frame, err := r.TraceTransaction(ctx, txHash)
+		require.NoError(t, err)
+		assert.Equal(t, "CALL", frame.Type)
+		require.Len(t, frame.Calls, 2)
+		created := frame.Calls[0]
+		assert.Equal(t, "CREATE", created.Type)
+		assert.Equal(t, common.HexToAddress("0x5"), *created.To)
+		require.Len(t, created.Calls, 1)
+		assert.Equal(t, "SELFDESTRUCT", created.Calls[0].Type)
+		failing := frame.FailingCall()
+		require.NotNil(t, failing)
+		assert.Equal(t, "DELEGATECALL", failing.Type)
+		assert.Equal(t, "boom", failing.RevertReason)
+		assert.Equal(t, int32(1), debugCalls.Load())
+
+		// the dialect is remembered
+		prestate, err := r.TraceTransactionPrestate(ctx, txHash)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), debugCalls.Load())
+		account := prestate[common.HexToAddress("0x1")]
+		require.NotNil(t, account)
+		assert.Equal(t, big.NewInt(0x10), account.Balance.ToInt())
+		assert.Equal(t, uint64(3), account.Nonce)
+		assert.Empty(t, account.Code)
+		assert.Equal(t, common.HexToHash("0x2"), account.Storage[common.HexToHash("0x1")])
+		assert.Equal(t, &client.PrestateAccount{}, prestate[common.HexToAddress("0x5")])
+	})
+
+	t.Run("returns errors of neither dialect", func(t *testing.T) {
+		r := newRPCClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			resp.Error.Code = -32000
+			resp.Error.Message = "transaction not found"
+			return
+		})
+		_, err := r.TraceTransaction(tests.Context(t), txHash)
+		require.ErrorContains(t, err, "transaction not found")
+	})
+}
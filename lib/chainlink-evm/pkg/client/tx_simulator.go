@@ -2,31 +2,177 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
 )
 
 type simulatorClient interface {
 	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
 }
 
-// ZK chains can return an out-of-counters error
-// This method allows a caller to determine if a tx would fail due to OOC error by simulating the transaction
-// Used as an entry point in case custom simulation is required across different chains
-func SimulateTransaction(ctx context.Context, client simulatorClient, lggr logger.SugaredLogger, chainType chaintype.ChainType, msg ethereum.CallMsg) *SendError {
-	err := simulateTransactionDefault(ctx, client, msg)
-	return NewSendError(err)
+// SimulationResult is the outcome of simulating a transaction.
+type SimulationResult struct {
+	// GasLimit is the gas the transaction is estimated to use. On Arbitrum it includes the gas charged for posting the
+	// transaction's data to L1, and on zkSync the gas charged for its pubdata.
+	GasLimit uint64
+	// L1Fee is the fee in wei charged separately for posting the transaction's data to L1. It is nil if the chain does
+	// not charge it separately, or if it could not be estimated.
+	L1Fee *big.Int
+	// OutOfCounters is set if the transaction would exceed the zk counters of the chain, so it could never be included.
+	OutOfCounters bool
+}
+
+// SimulateTransaction estimates the cost of msg with the strategy of the given chain type, returning a SendError if
+// the simulation failed, e.g. because the transaction would revert or exceed the zk counters of the chain. The result
+// is never nil, so its OutOfCounters flag can be checked whether or not the simulation failed.
+func SimulateTransaction(ctx context.Context, client simulatorClient, lggr logger.SugaredLogger, chainType chaintype.ChainType, msg ethereum.CallMsg) (*SimulationResult, *SendError) {
+	var result *SimulationResult
+	var err error
+	switch chainType {
+	case chaintype.ChainZkSync:
+		result, err = simulateTransactionZkSync(ctx, client, msg)
+	case chaintype.ChainArbitrum:
+		result, err = simulateTransactionArbitrum(ctx, client, msg)
+	case chaintype.ChainOptimismBedrock:
+		result, err = simulateTransactionWithL1Fee(ctx, client, lggr, opGasPriceOracleAddress, msg)
+	case chaintype.ChainScroll:
+		result, err = simulateTransactionWithL1Fee(ctx, client, lggr, scrollL1GasPriceOracleAddress, msg)
+	default:
+		// zkEVM and XLayer return out-of-counters errors from eth_estimateGas
+		result, err = simulateTransactionDefault(ctx, client, msg)
+	}
+	sendErr := NewSendError(err)
+	if sendErr != nil {
+		return &SimulationResult{OutOfCounters: sendErr.IsTerminallyStuckConfigError(nil)}, sendErr
+	}
+	return result, nil
 }
 
 // eth_estimateGas returns out-of-counters (OOC) error if the transaction would result in an overflow
-func simulateTransactionDefault(ctx context.Context, client simulatorClient, msg ethereum.CallMsg) error {
-	var result hexutil.Big
-	return client.CallContext(ctx, &result, "eth_estimateGas", toCallArg(msg), "pending")
+func simulateTransactionDefault(ctx context.Context, client simulatorClient, msg ethereum.CallMsg) (*SimulationResult, error) {
+	var result hexutil.Uint64
+	if err := client.CallContext(ctx, &result, "eth_estimateGas", toCallArg(msg), "pending"); err != nil {
+		return nil, err
+	}
+	return &SimulationResult{GasLimit: uint64(result)}, nil
+}
+
+// zks_estimateFee estimates the gas of the transaction including its pubdata, which zkSync charges as gas
+func simulateTransactionZkSync(ctx context.Context, client simulatorClient, msg ethereum.CallMsg) (*SimulationResult, error) {
+	var result struct {
+		GasLimit hexutil.Big `json:"gas_limit"`
+	}
+	if err := client.CallContext(ctx, &result, "zks_estimateFee", toCallArg(msg)); err != nil {
+		return nil, err
+	}
+	gasLimit := result.GasLimit.ToInt()
+	if !gasLimit.IsUint64() {
+		return nil, fmt.Errorf("zks_estimateFee returned invalid gas limit: %s", gasLimit)
+	}
+	return &SimulationResult{GasLimit: gasLimit.Uint64()}, nil
+}
+
+const (
+	// arbNodeInterfaceAddress is the address of Arbitrum's NodeInterface, a virtual contract only available through RPC
+	// https://github.com/OffchainLabs/nitro-contracts/blob/main/src/node-interface/NodeInterface.sol
+	arbNodeInterfaceAddress = "0x00000000000000000000000000000000000000C8"
+	// `function gasEstimateComponents(address to, bool contractCreation, bytes calldata data) external payable
+	// returns (uint64 gasEstimate, uint64 gasEstimateForL1, uint256 baseFee, uint256 l1BaseFeeEstimate);`
+	arbGasEstimateComponentsABI = `[{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"bool","name":"contractCreation","type":"bool"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"gasEstimateComponents","outputs":[{"internalType":"uint64","name":"gasEstimate","type":"uint64"},{"internalType":"uint64","name":"gasEstimateForL1","type":"uint64"},{"internalType":"uint256","name":"baseFee","type":"uint256"},{"internalType":"uint256","name":"l1BaseFeeEstimate","type":"uint256"}],"stateMutability":"payable","type":"function"}]`
+
+	// opGasPriceOracleAddress is the address of the OP Stack GasPriceOracle predeploy
+	opGasPriceOracleAddress = "0x420000000000000000000000000000000000000F"
+	// scrollL1GasPriceOracleAddress is the address of Scroll's L1GasPriceOracle predeploy
+	scrollL1GasPriceOracleAddress = "0x5300000000000000000000000000000000000002"
+	// `function getL1Fee(bytes memory _data) external view returns (uint256);`
+	getL1FeeABI = `[{"inputs":[{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"getL1Fee","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+)
+
+var (
+	arbNodeInterface  = evmtypes.MustGetABI(arbGasEstimateComponentsABI)
+	l1GasPriceOracles = evmtypes.MustGetABI(getL1FeeABI)
+)
+
+// NodeInterface.gasEstimateComponents simulates the transaction like eth_estimateGas. Its gasEstimate already includes
+// the gas charged for posting the transaction's data to L1, so the L1 fee is not reported separately.
+func simulateTransactionArbitrum(ctx context.Context, client simulatorClient, msg ethereum.CallMsg) (*SimulationResult, error) {
+	var to common.Address
+	if msg.To != nil {
+		to = *msg.To
+	}
+	data, err := arbNodeInterface.Pack("gasEstimateComponents", to, msg.To == nil, msg.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack gasEstimateComponents: %w", err)
+	}
+	call := msg
+	nodeInterface := common.HexToAddress(arbNodeInterfaceAddress)
+	call.To = &nodeInterface
+	call.Data = data
+	var result hexutil.Bytes
+	if err = client.CallContext(ctx, &result, "eth_call", toCallArg(call), "pending"); err != nil {
+		return nil, err
+	}
+	out, err := arbNodeInterface.Unpack("gasEstimateComponents", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack gasEstimateComponents result: %w", err)
+	}
+	return &SimulationResult{GasLimit: out[0].(uint64)}, nil
+}
+
+// eth_estimateGas estimates the gas for execution on L2, and the L1 fee charged on top of it is estimated by the
+// chain's gas price oracle from the serialized transaction. The transaction is unsigned, so the L1 fee is slightly
+// underestimated. Failing to estimate the L1 fee does not fail the simulation.
+func simulateTransactionWithL1Fee(ctx context.Context, client simulatorClient, lggr logger.SugaredLogger, oracleAddress string, msg ethereum.CallMsg) (*SimulationResult, error) {
+	result, err := simulateTransactionDefault(ctx, client, msg)
+	if err != nil {
+		return nil, err
+	}
+	l1Fee, err := estimateL1Fee(ctx, client, oracleAddress, msg, result.GasLimit)
+	if err != nil {
+		lggr.Warnw("Failed to estimate L1 fee during transaction simulation", "err", err, "oracleAddress", oracleAddress)
+		return result, nil
+	}
+	result.L1Fee = l1Fee
+	return result, nil
+}
+
+func estimateL1Fee(ctx context.Context, client simulatorClient, oracleAddress string, msg ethereum.CallMsg, gasLimit uint64) (*big.Int, error) {
+	tx, err := types.NewTx(&types.DynamicFeeTx{
+		To:        msg.To,
+		Gas:       gasLimit,
+		GasFeeCap: msg.GasFeeCap,
+		GasTipCap: msg.GasTipCap,
+		Value:     msg.Value,
+		Data:      msg.Data,
+	}).MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+	data, err := l1GasPriceOracles.Pack("getL1Fee", tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack getL1Fee: %w", err)
+	}
+	oracle := common.HexToAddress(oracleAddress)
+	call := ethereum.CallMsg{To: &oracle, Data: data}
+	var result hexutil.Bytes
+	if err = client.CallContext(ctx, &result, "eth_call", toCallArg(call), "latest"); err != nil {
+		return nil, err
+	}
+	out, err := l1GasPriceOracles.Unpack("getL1Fee", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack getL1Fee result: %w", err)
+	}
+	return out[0].(*big.Int), nil
 }
 
 func toCallArg(msg ethereum.CallMsg) interface{} {
@@ -1,9 +1,13 @@
 package client_test
 
 import (
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 )
 
@@ -44,8 +49,11 @@ func TestSimulateTx_Default(t *testing.T) {
 			To:   &toAddress,
 			Data: []byte("0x00"),
 		}
-		sendErr := client.SimulateTransaction(ctx, ethClient, logger.TestSugared(t), "", msg)
+		result, sendErr := client.SimulateTransaction(ctx, ethClient, logger.TestSugared(t), "", msg)
 		require.Empty(t, sendErr)
+		assert.Equal(t, uint64(0x100), result.GasLimit)
+		assert.Nil(t, result.L1Fee)
+		assert.False(t, result.OutOfCounters)
 	})
 
 	t.Run("returns error if simulation returns zk out-of-counters error", func(t *testing.T) {
@@ -74,8 +82,9 @@ func TestSimulateTx_Default(t *testing.T) {
 			To:   &toAddress,
 			Data: []byte("0x00"),
 		}
-		sendErr := client.SimulateTransaction(ctx, ethClient, logger.TestSugared(t), "", msg)
+		result, sendErr := client.SimulateTransaction(ctx, ethClient, logger.TestSugared(t), chaintype.ChainZkEvm, msg)
 		require.True(t, sendErr.IsTerminallyStuckConfigError(nil))
+		assert.True(t, result.OutOfCounters)
 	})
 
 	t.Run("returns without error if simulation returns non-OOC error", func(t *testing.T) {
@@ -103,7 +112,119 @@ func TestSimulateTx_Default(t *testing.T) {
 			To:   &toAddress,
 			Data: []byte("0x00"),
 		}
-		sendErr := client.SimulateTransaction(ctx, ethClient, logger.TestSugared(t), "", msg)
+		result, sendErr := client.SimulateTransaction(ctx, ethClient, logger.TestSugared(t), "", msg)
 		require.False(t, sendErr.IsTerminallyStuckConfigError(nil))
+		assert.False(t, result.OutOfCounters)
+	})
+}
+
+func TestSimulateTx_ChainSpecific(t *testing.T) {
+	t.Parallel()
+
+	fromAddress := testutils.NewAddress()
+	toAddress := testutils.NewAddress()
+	msg := ethereum.CallMsg{
+		From: fromAddress,
+		To:   &toAddress,
+		Data: []byte{1, 2, 3},
+	}
+	newClient := func(t *testing.T, handler testutils.JSONRPCHandler) client.Client {
+		wsURL := testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "eth_subscribe":
+				resp.Result = `"0x00"`
+				resp.Notify = headResult
+				return
+			case "eth_unsubscribe":
+				resp.Result = "true"
+				return
+			}
+			return handler(method, params)
+		}).WSURL().String()
+		return mustNewChainClient(t, wsURL)
+	}
+
+	t.Run("zkSync estimates fees", func(t *testing.T) {
+		ethClient := newClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			if method == "zks_estimateFee" {
+				resp.Result = `{"gas_limit":"0x1000","gas_per_pubdata_limit":"0x320","max_fee_per_gas":"0x1","max_priority_fee_per_gas":"0x0"}`
+			}
+			return
+		})
+		result, sendErr := client.SimulateTransaction(tests.Context(t), ethClient, logger.TestSugared(t), chaintype.ChainZkSync, msg)
+		require.Empty(t, sendErr)
+		assert.Equal(t, uint64(0x1000), result.GasLimit)
+		assert.Nil(t, result.L1Fee)
+	})
+
+	t.Run("Arbitrum estimates gas components", func(t *testing.T) {
+		ethClient := newClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			if method == "eth_call" && strings.EqualFold(params.Get("0.to").String(), "0x00000000000000000000000000000000000000C8") {
+				// gasEstimate, gasEstimateForL1, baseFee, l1BaseFeeEstimate
+				resp.Result = fmt.Sprintf(`"0x%064x%064x%064x%064x"`, 100_000, 20_000, 100_000_000, 1)
+			}
+			return
+		})
+		result, sendErr := client.SimulateTransaction(tests.Context(t), ethClient, logger.TestSugared(t), chaintype.ChainArbitrum, msg)
+		require.Empty(t, sendErr)
+		// gasEstimate includes the L1 gas, which is not charged on top of it
+		assert.Equal(t, uint64(100_000), result.GasLimit)
+		assert.Nil(t, result.L1Fee)
+	})
+
+	t.Run("Arbitrum returns reverts", func(t *testing.T) {
+		ethClient := newClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			if method == "eth_call" {
+				resp.Error.Code = 3
+				resp.Error.Message = "execution reverted"
+			}
+			return
+		})
+		result, sendErr := client.SimulateTransaction(tests.Context(t), ethClient, logger.TestSugared(t), chaintype.ChainArbitrum, msg)
+		require.ErrorContains(t, sendErr, "execution reverted")
+		assert.False(t, result.OutOfCounters)
+	})
+
+	for _, tc := range []struct {
+		chainType     chaintype.ChainType
+		oracleAddress string
+	}{
+		{chaintype.ChainOptimismBedrock, "0x420000000000000000000000000000000000000F"},
+		{chaintype.ChainScroll, "0x5300000000000000000000000000000000000002"},
+	} {
+		t.Run(string(tc.chainType)+" includes the L1 fee", func(t *testing.T) {
+			ethClient := newClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+				switch method {
+				case "eth_estimateGas":
+					resp.Result = `"0x5208"`
+				case "eth_call":
+					if strings.EqualFold(params.Get("0.to").String(), tc.oracleAddress) {
+						resp.Result = fmt.Sprintf(`"0x%064x"`, 0x1234)
+					}
+				}
+				return
+			})
+			result, sendErr := client.SimulateTransaction(tests.Context(t), ethClient, logger.TestSugared(t), tc.chainType, msg)
+			require.Empty(t, sendErr)
+			assert.Equal(t, uint64(0x5208), result.GasLimit)
+			assert.Equal(t, big.NewInt(0x1234), result.L1Fee)
+		})
+	}
+
+	t.Run("L1 fee failures don't fail the simulation", func(t *testing.T) {
+		ethClient := newClient(t, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "eth_estimateGas":
+				resp.Result = `"0x5208"`
+			case "eth_call":
+				resp.Error.Code = -32000
+				resp.Error.Message = "something went wrong"
+			}
+			return
+		})
+		result, sendErr := client.SimulateTransaction(tests.Context(t), ethClient, logger.TestSugared(t), chaintype.ChainOptimismBedrock, msg)
+		require.Empty(t, sendErr)
+		assert.Equal(t, uint64(0x5208), result.GasLimit)
+		assert.Nil(t, result.L1Fee)
 	})
 }
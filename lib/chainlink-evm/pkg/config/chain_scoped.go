@@ -115,6 +115,22 @@ func (e *EVMConfig) BackupLogPollerBlockDelay() uint64 {
 	return *e.C.BackupLogPollerBlockDelay
 }
 
+func (e *EVMConfig) LogAutoRecoverFinalityViolation() bool {
+	return *e.C.LogAutoRecoverFinalityViolation
+}
+
+func (e *EVMConfig) LogBloomFilterEnabled() bool {
+	return *e.C.LogBloomFilterEnabled
+}
+
+func (e *EVMConfig) LogReorgAuditRetention() time.Duration {
+	return e.C.LogReorgAuditRetention.Duration()
+}
+
+func (e *EVMConfig) LogFilterStatsInterval() time.Duration {
+	return e.C.LogFilterStatsInterval.Duration()
+}
+
 func (e *EVMConfig) NonceAutoSync() bool {
 	return *e.C.NonceAutoSync
 }
@@ -183,6 +199,13 @@ func (e *EVMConfig) LogBroadcasterEnabled() bool {
 	return e.C.LogBroadcasterEnabled == nil || *e.C.LogBroadcasterEnabled
 }
 
+func (e *EVMConfig) LogBroadcasterBackend() string {
+	if e.C.LogBroadcasterBackend == nil {
+		return toml.LogBroadcasterBackendWebsocket
+	}
+	return *e.C.LogBroadcasterBackend
+}
+
 func (e *EVMConfig) LogPrunePageSize() uint32 {
 	return *e.C.LogPrunePageSize
 }
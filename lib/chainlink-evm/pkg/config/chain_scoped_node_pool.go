@@ -46,6 +46,18 @@ func (n *NodePoolConfig) VerifyChainID() bool {
 	return true
 }
 
+func (n *NodePoolConfig) ResponseCacheSize() uint32 {
+	return *n.C.ResponseCacheSize
+}
+
+func (n *NodePoolConfig) QuorumNodes() uint32 {
+	return *n.C.QuorumNodes
+}
+
+func (n *NodePoolConfig) QuorumThreshold() uint32 {
+	return *n.C.QuorumThreshold
+}
+
 func (n *NodePoolConfig) Errors() ClientErrors { return &clientErrorsConfig{c: n.C.Errors} }
 
 func (n *NodePoolConfig) EnforceRepeatableRead() bool {
@@ -40,6 +40,10 @@ type EVM interface {
 	LogBackfillBatchSize() uint32
 	LogKeepBlocksDepth() uint32
 	BackupLogPollerBlockDelay() uint64
+	LogAutoRecoverFinalityViolation() bool
+	LogBloomFilterEnabled() bool
+	LogReorgAuditRetention() time.Duration
+	LogFilterStatsInterval() time.Duration
 	LogPollInterval() time.Duration
 	LogPrunePageSize() uint32
 	MinContractPayment() *commonassets.Link
@@ -47,6 +51,7 @@ type EVM interface {
 	NonceAutoSync() bool
 	OperatorFactoryAddress() string
 	LogBroadcasterEnabled() bool
+	LogBroadcasterBackend() string
 	RPCDefaultBatchSize() uint32
 	NodeNoNewHeadsThreshold() time.Duration
 	FinalizedBlockOffset() uint32
@@ -211,6 +216,9 @@ type NodePool interface {
 	DeathDeclarationDelay() time.Duration
 	NewHeadsPollInterval() time.Duration
 	VerifyChainID() bool
+	ResponseCacheSize() uint32
+	QuorumNodes() uint32
+	QuorumThreshold() uint32
 }
 
 type ChainScopedConfig interface {
@@ -220,6 +220,10 @@ func TestChainScopedConfig(t *testing.T) {
 
 			assert.False(t, cfg3.EVM().LogBroadcasterEnabled())
 		})
+
+		t.Run("LogBroadcasterBackend defaults to websocket", func(t *testing.T) {
+			assert.Equal(t, "websocket", cfg.EVM().LogBroadcasterBackend())
+		})
 	})
 
 	t.Run("EVM.Transactions.Enabled", func(t *testing.T) {
@@ -802,6 +802,51 @@ func (_c *EVM_LinkContractAddress_Call) RunAndReturn(run func() string) *EVM_Lin
 	return _c
 }
 
+// LogAutoRecoverFinalityViolation provides a mock function with no fields
+func (_m *EVM) LogAutoRecoverFinalityViolation() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAutoRecoverFinalityViolation")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EVM_LogAutoRecoverFinalityViolation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAutoRecoverFinalityViolation'
+type EVM_LogAutoRecoverFinalityViolation_Call struct {
+	*mock.Call
+}
+
+// LogAutoRecoverFinalityViolation is a helper method to define mock.On call
+func (_e *EVM_Expecter) LogAutoRecoverFinalityViolation() *EVM_LogAutoRecoverFinalityViolation_Call {
+	return &EVM_LogAutoRecoverFinalityViolation_Call{Call: _e.mock.On("LogAutoRecoverFinalityViolation")}
+}
+
+func (_c *EVM_LogAutoRecoverFinalityViolation_Call) Run(run func()) *EVM_LogAutoRecoverFinalityViolation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EVM_LogAutoRecoverFinalityViolation_Call) Return(_a0 bool) *EVM_LogAutoRecoverFinalityViolation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EVM_LogAutoRecoverFinalityViolation_Call) RunAndReturn(run func() bool) *EVM_LogAutoRecoverFinalityViolation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LogBackfillBatchSize provides a mock function with no fields
 func (_m *EVM) LogBackfillBatchSize() uint32 {
 	ret := _m.Called()
@@ -847,6 +892,96 @@ func (_c *EVM_LogBackfillBatchSize_Call) RunAndReturn(run func() uint32) *EVM_Lo
 	return _c
 }
 
+// LogBloomFilterEnabled provides a mock function with no fields
+func (_m *EVM) LogBloomFilterEnabled() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogBloomFilterEnabled")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EVM_LogBloomFilterEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogBloomFilterEnabled'
+type EVM_LogBloomFilterEnabled_Call struct {
+	*mock.Call
+}
+
+// LogBloomFilterEnabled is a helper method to define mock.On call
+func (_e *EVM_Expecter) LogBloomFilterEnabled() *EVM_LogBloomFilterEnabled_Call {
+	return &EVM_LogBloomFilterEnabled_Call{Call: _e.mock.On("LogBloomFilterEnabled")}
+}
+
+func (_c *EVM_LogBloomFilterEnabled_Call) Run(run func()) *EVM_LogBloomFilterEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EVM_LogBloomFilterEnabled_Call) Return(_a0 bool) *EVM_LogBloomFilterEnabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EVM_LogBloomFilterEnabled_Call) RunAndReturn(run func() bool) *EVM_LogBloomFilterEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogBroadcasterBackend provides a mock function with no fields
+func (_m *EVM) LogBroadcasterBackend() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogBroadcasterBackend")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// EVM_LogBroadcasterBackend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogBroadcasterBackend'
+type EVM_LogBroadcasterBackend_Call struct {
+	*mock.Call
+}
+
+// LogBroadcasterBackend is a helper method to define mock.On call
+func (_e *EVM_Expecter) LogBroadcasterBackend() *EVM_LogBroadcasterBackend_Call {
+	return &EVM_LogBroadcasterBackend_Call{Call: _e.mock.On("LogBroadcasterBackend")}
+}
+
+func (_c *EVM_LogBroadcasterBackend_Call) Run(run func()) *EVM_LogBroadcasterBackend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EVM_LogBroadcasterBackend_Call) Return(_a0 string) *EVM_LogBroadcasterBackend_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EVM_LogBroadcasterBackend_Call) RunAndReturn(run func() string) *EVM_LogBroadcasterBackend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LogBroadcasterEnabled provides a mock function with no fields
 func (_m *EVM) LogBroadcasterEnabled() bool {
 	ret := _m.Called()
@@ -892,6 +1027,51 @@ func (_c *EVM_LogBroadcasterEnabled_Call) RunAndReturn(run func() bool) *EVM_Log
 	return _c
 }
 
+// LogFilterStatsInterval provides a mock function with no fields
+func (_m *EVM) LogFilterStatsInterval() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogFilterStatsInterval")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// EVM_LogFilterStatsInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogFilterStatsInterval'
+type EVM_LogFilterStatsInterval_Call struct {
+	*mock.Call
+}
+
+// LogFilterStatsInterval is a helper method to define mock.On call
+func (_e *EVM_Expecter) LogFilterStatsInterval() *EVM_LogFilterStatsInterval_Call {
+	return &EVM_LogFilterStatsInterval_Call{Call: _e.mock.On("LogFilterStatsInterval")}
+}
+
+func (_c *EVM_LogFilterStatsInterval_Call) Run(run func()) *EVM_LogFilterStatsInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EVM_LogFilterStatsInterval_Call) Return(_a0 time.Duration) *EVM_LogFilterStatsInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EVM_LogFilterStatsInterval_Call) RunAndReturn(run func() time.Duration) *EVM_LogFilterStatsInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LogKeepBlocksDepth provides a mock function with no fields
 func (_m *EVM) LogKeepBlocksDepth() uint32 {
 	ret := _m.Called()
@@ -1027,6 +1207,51 @@ func (_c *EVM_LogPrunePageSize_Call) RunAndReturn(run func() uint32) *EVM_LogPru
 	return _c
 }
 
+// LogReorgAuditRetention provides a mock function with no fields
+func (_m *EVM) LogReorgAuditRetention() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogReorgAuditRetention")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// EVM_LogReorgAuditRetention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogReorgAuditRetention'
+type EVM_LogReorgAuditRetention_Call struct {
+	*mock.Call
+}
+
+// LogReorgAuditRetention is a helper method to define mock.On call
+func (_e *EVM_Expecter) LogReorgAuditRetention() *EVM_LogReorgAuditRetention_Call {
+	return &EVM_LogReorgAuditRetention_Call{Call: _e.mock.On("LogReorgAuditRetention")}
+}
+
+func (_c *EVM_LogReorgAuditRetention_Call) Run(run func()) *EVM_LogReorgAuditRetention_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EVM_LogReorgAuditRetention_Call) Return(_a0 time.Duration) *EVM_LogReorgAuditRetention_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EVM_LogReorgAuditRetention_Call) RunAndReturn(run func() time.Duration) *EVM_LogReorgAuditRetention_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MinContractPayment provides a mock function with no fields
 func (_m *EVM) MinContractPayment() *assets.Link {
 	ret := _m.Called()
@@ -343,6 +343,10 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 		if c.LogBroadcasterEnabled != nil {
 			logBroadcasterEnabled = *c.LogBroadcasterEnabled
 		}
+		if c.LogBroadcasterBackend != nil && *c.LogBroadcasterBackend == LogBroadcasterBackendLogPoller {
+			// logs are read from the database, so the LogBroadcaster doesn't need a websocket connection
+			logBroadcasterEnabled = false
+		}
 
 		if c.NodePool.NewHeadsPollInterval != nil {
 			newHeadsPollingInterval = *c.NodePool.NewHeadsPollInterval
@@ -388,30 +392,35 @@ func (c *EVMConfig) TOMLString() (string, error) {
 }
 
 type Chain struct {
-	AutoCreateKey                *bool
-	BlockBackfillDepth           *uint32
-	BlockBackfillSkip            *bool
-	ChainType                    *chaintype.Config
-	FinalityDepth                *uint32
-	SafeDepth                    *uint32
-	FinalityTagEnabled           *bool
-	FlagsContractAddress         *types.EIP55Address
-	LinkContractAddress          *types.EIP55Address
-	LogBackfillBatchSize         *uint32
-	LogPollInterval              *commonconfig.Duration
-	LogKeepBlocksDepth           *uint32
-	LogPrunePageSize             *uint32
-	BackupLogPollerBlockDelay    *uint64
-	MinIncomingConfirmations     *uint32
-	MinContractPayment           *commonassets.Link
-	NonceAutoSync                *bool
-	NoNewHeadsThreshold          *commonconfig.Duration
-	OperatorFactoryAddress       *types.EIP55Address
-	LogBroadcasterEnabled        *bool
-	RPCDefaultBatchSize          *uint32
-	RPCBlockQueryDelay           *uint16
-	FinalizedBlockOffset         *uint32
-	NoNewFinalizedHeadsThreshold *commonconfig.Duration
+	AutoCreateKey                   *bool
+	BlockBackfillDepth              *uint32
+	BlockBackfillSkip               *bool
+	ChainType                       *chaintype.Config
+	FinalityDepth                   *uint32
+	SafeDepth                       *uint32
+	FinalityTagEnabled              *bool
+	FlagsContractAddress            *types.EIP55Address
+	LinkContractAddress             *types.EIP55Address
+	LogBackfillBatchSize            *uint32
+	LogPollInterval                 *commonconfig.Duration
+	LogKeepBlocksDepth              *uint32
+	LogPrunePageSize                *uint32
+	BackupLogPollerBlockDelay       *uint64
+	LogAutoRecoverFinalityViolation *bool
+	LogBloomFilterEnabled           *bool
+	LogReorgAuditRetention          *commonconfig.Duration
+	LogFilterStatsInterval          *commonconfig.Duration
+	MinIncomingConfirmations        *uint32
+	MinContractPayment              *commonassets.Link
+	NonceAutoSync                   *bool
+	NoNewHeadsThreshold             *commonconfig.Duration
+	OperatorFactoryAddress          *types.EIP55Address
+	LogBroadcasterEnabled           *bool
+	LogBroadcasterBackend           *string
+	RPCDefaultBatchSize             *uint32
+	RPCBlockQueryDelay              *uint16
+	FinalizedBlockOffset            *uint32
+	NoNewFinalizedHeadsThreshold    *commonconfig.Duration
 
 	Transactions   Transactions      `toml:",omitempty"`
 	BalanceMonitor BalanceMonitor    `toml:",omitempty"`
@@ -424,12 +433,28 @@ type Chain struct {
 	Workflow       Workflow          `toml:",omitempty"`
 }
 
+const (
+	// LogBroadcasterBackendWebsocket subscribes to logs over the websocket connections of the primary nodes.
+	LogBroadcasterBackendWebsocket = "websocket"
+	// LogBroadcasterBackendLogPoller reads logs indexed by the LogPoller, for chains without websocket endpoints.
+	LogBroadcasterBackendLogPoller = "logpoller"
+)
+
 func (c *Chain) ValidateConfig() (err error) {
 	if !c.ChainType.ChainType().IsValid() {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "ChainType", Value: c.ChainType.ChainType(),
 			Msg: chaintype.ErrInvalid.Error()})
 	}
 
+	if c.LogBroadcasterBackend != nil {
+		switch *c.LogBroadcasterBackend {
+		case LogBroadcasterBackendWebsocket, LogBroadcasterBackendLogPoller:
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "LogBroadcasterBackend", Value: *c.LogBroadcasterBackend,
+				Msg: fmt.Sprintf("must be %q or %q", LogBroadcasterBackendWebsocket, LogBroadcasterBackendLogPoller)})
+		}
+	}
+
 	if c.GasEstimator.BumpTxDepth != nil && *c.GasEstimator.BumpTxDepth > *c.Transactions.MaxInFlight {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "GasEstimator.BumpTxDepth", Value: *c.GasEstimator.BumpTxDepth,
 			Msg: "must be less than or equal to Transactions.MaxInFlight"})
@@ -1085,6 +1110,9 @@ type NodePool struct {
 	DeathDeclarationDelay      *commonconfig.Duration
 	NewHeadsPollInterval       *commonconfig.Duration
 	VerifyChainID              *bool
+	ResponseCacheSize          *uint32
+	QuorumNodes                *uint32
+	QuorumThreshold            *uint32
 }
 
 func (p *NodePool) setFrom(f *NodePool) {
@@ -1126,6 +1154,18 @@ func (p *NodePool) setFrom(f *NodePool) {
 		p.VerifyChainID = v
 	}
 
+	if v := f.ResponseCacheSize; v != nil {
+		p.ResponseCacheSize = v
+	}
+
+	if v := f.QuorumNodes; v != nil {
+		p.QuorumNodes = v
+	}
+
+	if v := f.QuorumThreshold; v != nil {
+		p.QuorumThreshold = v
+	}
+
 	p.Errors.setFrom(&f.Errors)
 }
 
@@ -1140,6 +1180,14 @@ func (p *NodePool) ValidateConfig(finalityTagEnabled *bool) (err error) {
 				Msg: "must be greater than 0"})
 		}
 	}
+	if p.QuorumNodes != nil && *p.QuorumNodes > 0 {
+		if p.QuorumThreshold == nil {
+			err = multierr.Append(err, commonconfig.ErrMissing{Name: "QuorumThreshold", Msg: "required when QuorumNodes is set"})
+		} else if t := *p.QuorumThreshold; t <= *p.QuorumNodes/2 || t > *p.QuorumNodes {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "QuorumThreshold", Value: t,
+				Msg: fmt.Sprintf("must be a majority of QuorumNodes (%d)", *p.QuorumNodes)})
+		}
+	}
 	return
 }
 
@@ -1183,6 +1231,11 @@ type Node struct {
 	HTTPURLExtraWrite *commonconfig.URL
 	SendOnly          *bool
 	Order             *int32
+	VerifyHeaders     *bool
+	// RequestsPerSecond, RequestBurst and HeavyRequestsPerSecond configure client-side rate limiting of this node
+	RequestsPerSecond      *uint32
+	RequestBurst           *uint32
+	HeavyRequestsPerSecond *uint32
 }
 
 func (n *Node) ValidateConfig() (err error) {
@@ -1213,6 +1266,19 @@ func (n *Node) ValidateConfig() (err error) {
 		}
 	}
 
+	// rate limits only apply to calls sent over HTTP
+	if n.HTTPURL == nil || n.HTTPURL.IsZero() {
+		if n.RequestsPerSecond != nil && *n.RequestsPerSecond > 0 {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "RequestsPerSecond", Value: *n.RequestsPerSecond, Msg: "requires HTTPURL"})
+		}
+		if n.RequestBurst != nil && *n.RequestBurst > 0 {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "RequestBurst", Value: *n.RequestBurst, Msg: "requires HTTPURL"})
+		}
+		if n.HeavyRequestsPerSecond != nil && *n.HeavyRequestsPerSecond > 0 {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "HeavyRequestsPerSecond", Value: *n.HeavyRequestsPerSecond, Msg: "requires HTTPURL"})
+		}
+	}
+
 	if n.HTTPURLExtraWrite != nil {
 		switch n.HTTPURLExtraWrite.Scheme {
 		case "http", "https":
@@ -1250,6 +1316,18 @@ func (n *Node) SetFrom(f *Node) {
 	if f.Order != nil {
 		n.Order = f.Order
 	}
+	if f.VerifyHeaders != nil {
+		n.VerifyHeaders = f.VerifyHeaders
+	}
+	if f.RequestsPerSecond != nil {
+		n.RequestsPerSecond = f.RequestsPerSecond
+	}
+	if f.RequestBurst != nil {
+		n.RequestBurst = f.RequestBurst
+	}
+	if f.HeavyRequestsPerSecond != nil {
+		n.HeavyRequestsPerSecond = f.HeavyRequestsPerSecond
+	}
 }
 
 func ChainIDInt64(cid string) (int64, error) {
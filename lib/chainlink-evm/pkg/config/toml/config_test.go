@@ -48,6 +48,27 @@ func TestEVMConfig_ValidateConfig(t *testing.T) {
 	}
 }
 
+func TestNode_ValidateConfig_RateLimits(t *testing.T) {
+	name := "fake"
+	n := &Node{
+		Name:                   &name,
+		WSURL:                  config.MustParseURL("wss://foo.test/ws"),
+		HTTPURL:                config.MustParseURL("http://foo.test"),
+		RequestsPerSecond:      ptr[uint32](50),
+		RequestBurst:           ptr[uint32](100),
+		HeavyRequestsPerSecond: ptr[uint32](5),
+	}
+	require.NoError(t, n.ValidateConfig())
+
+	// calls are only rate limited over HTTP
+	n.HTTPURL = nil
+	err := n.ValidateConfig()
+	require.Error(t, err)
+	for _, field := range []string{"RequestsPerSecond", "RequestBurst", "HeavyRequestsPerSecond"} {
+		assert.ErrorContains(t, err, field+": invalid value")
+	}
+}
+
 func TestDefaults_fieldsNotNil(t *testing.T) {
 	unknown := Defaults(nil)
 
@@ -255,21 +276,26 @@ var fullConfig = EVMConfig{
 			},
 		},
 
-		LinkContractAddress:          ptr(types.MustEIP55Address("0x538aAaB4ea120b2bC2fe5D296852D948F07D849e")),
-		LogBackfillBatchSize:         ptr[uint32](17),
-		LogPollInterval:              config.MustNewDuration(time.Minute),
-		LogKeepBlocksDepth:           ptr[uint32](100000),
-		LogPrunePageSize:             ptr[uint32](0),
-		BackupLogPollerBlockDelay:    ptr[uint64](532),
-		MinContractPayment:           commonassets.NewLinkFromJuels(math.MaxInt64),
-		MinIncomingConfirmations:     ptr[uint32](13),
-		NonceAutoSync:                ptr(true),
-		NoNewHeadsThreshold:          config.MustNewDuration(time.Minute),
-		OperatorFactoryAddress:       ptr(types.MustEIP55Address("0xa5B85635Be42F21f94F28034B7DA440EeFF0F418")),
-		LogBroadcasterEnabled:        ptr(true),
-		RPCDefaultBatchSize:          ptr[uint32](17),
-		RPCBlockQueryDelay:           ptr[uint16](10),
-		NoNewFinalizedHeadsThreshold: config.MustNewDuration(time.Hour),
+		LinkContractAddress:             ptr(types.MustEIP55Address("0x538aAaB4ea120b2bC2fe5D296852D948F07D849e")),
+		LogBackfillBatchSize:            ptr[uint32](17),
+		LogPollInterval:                 config.MustNewDuration(time.Minute),
+		LogKeepBlocksDepth:              ptr[uint32](100000),
+		LogPrunePageSize:                ptr[uint32](0),
+		BackupLogPollerBlockDelay:       ptr[uint64](532),
+		LogAutoRecoverFinalityViolation: ptr(true),
+		LogBloomFilterEnabled:           ptr(false),
+		LogReorgAuditRetention:          config.MustNewDuration(24 * time.Hour),
+		LogFilterStatsInterval:          config.MustNewDuration(30 * time.Minute),
+		MinContractPayment:              commonassets.NewLinkFromJuels(math.MaxInt64),
+		MinIncomingConfirmations:        ptr[uint32](13),
+		NonceAutoSync:                   ptr(true),
+		NoNewHeadsThreshold:             config.MustNewDuration(time.Minute),
+		OperatorFactoryAddress:          ptr(types.MustEIP55Address("0xa5B85635Be42F21f94F28034B7DA440EeFF0F418")),
+		LogBroadcasterEnabled:           ptr(true),
+		LogBroadcasterBackend:           ptr("logpoller"),
+		RPCDefaultBatchSize:             ptr[uint32](17),
+		RPCBlockQueryDelay:              ptr[uint16](10),
+		NoNewFinalizedHeadsThreshold:    config.MustNewDuration(time.Hour),
 
 		Transactions: Transactions{
 			Enabled:              ptr(true),
@@ -315,6 +341,9 @@ var fullConfig = EVMConfig{
 			DeathDeclarationDelay:      config.MustNewDuration(time.Minute),
 			VerifyChainID:              ptr(true),
 			NewHeadsPollInterval:       config.MustNewDuration(0),
+			ResponseCacheSize:          ptr[uint32](10000),
+			QuorumNodes:                ptr[uint32](3),
+			QuorumThreshold:            ptr[uint32](2),
 			Errors: ClientErrors{
 				NonceTooLow:                       ptr[string]("(: |^)nonce too low"),
 				NonceTooHigh:                      ptr[string]("(: |^)nonce too high"),
@@ -364,6 +393,11 @@ var fullConfig = EVMConfig{
 			HTTPURLExtraWrite: config.MustParseURL("https://foo.web/extra"),
 			SendOnly:          ptr(false),
 			Order:             ptr[int32](0),
+			VerifyHeaders:     ptr(true),
+
+			RequestsPerSecond:      ptr[uint32](50),
+			RequestBurst:           ptr[uint32](100),
+			HeavyRequestsPerSecond: ptr[uint32](5),
 		},
 	},
 }
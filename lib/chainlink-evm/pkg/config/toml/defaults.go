@@ -233,6 +233,18 @@ func (c *Chain) SetFrom(f *Chain) {
 	if v := f.BackupLogPollerBlockDelay; v != nil {
 		c.BackupLogPollerBlockDelay = v
 	}
+	if v := f.LogAutoRecoverFinalityViolation; v != nil {
+		c.LogAutoRecoverFinalityViolation = v
+	}
+	if v := f.LogBloomFilterEnabled; v != nil {
+		c.LogBloomFilterEnabled = v
+	}
+	if v := f.LogReorgAuditRetention; v != nil {
+		c.LogReorgAuditRetention = v
+	}
+	if v := f.LogFilterStatsInterval; v != nil {
+		c.LogFilterStatsInterval = v
+	}
 	if v := f.MinIncomingConfirmations; v != nil {
 		c.MinIncomingConfirmations = v
 	}
@@ -251,6 +263,9 @@ func (c *Chain) SetFrom(f *Chain) {
 	if v := f.LogBroadcasterEnabled; v != nil {
 		c.LogBroadcasterEnabled = v
 	}
+	if v := f.LogBroadcasterBackend; v != nil {
+		c.LogBroadcasterBackend = v
+	}
 	if v := f.RPCDefaultBatchSize; v != nil {
 		c.RPCDefaultBatchSize = v
 	}
@@ -182,3 +182,51 @@ func newPotentialCycleError(parent, child *evmtypes.Head) error {
 	return fmt.Errorf("expected head number to strictly decrease in 'child -> parent' relation: "+
 		"child(%s), parent(%s)", child.String(), parent.String())
 }
+
+// findReorg compares the previous longest chain prev with the new longest chain head, both linked through the
+// Parent pointers populated by headSet. It returns nil if head extends prev, or if the chains don't reach back far
+// enough to tell. Otherwise, the returned Reorg has an empty CommonAncestor if the branches don't meet within the
+// tracked history.
+func findReorg(prev, head *evmtypes.Head) *Reorg {
+	if prev == nil || head == nil || prev.Hash == head.Hash {
+		return nil
+	}
+
+	newChain := make(map[common.Hash]*evmtypes.Head)
+	lowest := head
+	for h := head; h != nil; h = h.Parent.Load() {
+		newChain[h.Hash] = h
+		lowest = h
+	}
+	if _, ok := newChain[prev.Hash]; ok || lowest.Number > prev.Number {
+		return nil
+	}
+
+	reorg := &Reorg{ToBlock: prev.Number}
+	var ancestor *evmtypes.Head
+	for h := prev; h != nil; h = h.Parent.Load() {
+		if _, ok := newChain[h.Hash]; ok {
+			ancestor = h
+			break
+		}
+		reorg.OldBranch = append(reorg.OldBranch, h.Hash)
+		reorg.FromBlock = h.Number
+	}
+	if ancestor == nil {
+		// the branches diverged below the tracked history, the whole tracked new chain replaces the old one
+		for h := head; h != nil; h = h.Parent.Load() {
+			reorg.NewBranch = append(reorg.NewBranch, h.Hash)
+		}
+		reorg.Depth = prev.Number - reorg.FromBlock + 1
+		return reorg
+	}
+
+	for h := head; h != nil && h.Hash != ancestor.Hash; h = h.Parent.Load() {
+		reorg.NewBranch = append(reorg.NewBranch, h.Hash)
+	}
+	reorg.CommonAncestor = ancestor.Hash
+	reorg.CommonAncestorNumber = ancestor.Number
+	reorg.FromBlock = ancestor.Number + 1
+	reorg.Depth = prev.Number - ancestor.Number
+	return reorg
+}
@@ -0,0 +1,140 @@
+package heads
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+var promReorgDepth = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "head_tracker_reorg_depth",
+	Help:    "Number of blocks removed from the longest chain by a reorg",
+	Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+}, []string{"evmChainID"})
+
+// Reorg describes a switch of the longest chain to another branch.
+type Reorg struct {
+	ChainID *big.Int
+	// CommonAncestor is the highest block shared by both branches. It is empty if the branches diverged below the
+	// tracked history, in which case the reorg may be deeper than Depth.
+	CommonAncestor       common.Hash
+	CommonAncestorNumber int64
+	// OldBranch holds the hashes of the blocks removed from the longest chain, from the highest to the lowest.
+	OldBranch []common.Hash
+	// NewBranch holds the hashes of the blocks added to the longest chain, from the highest to the lowest.
+	NewBranch []common.Hash
+	// Depth is the number of blocks removed from the longest chain.
+	Depth int64
+	// FromBlock and ToBlock are the first and last block number, both inclusive, whose block was replaced or removed.
+	FromBlock, ToBlock int64
+}
+
+// ReorgListener is notified of reorgs by a ReorgBroadcaster.
+type ReorgListener interface {
+	// OnReorg is called synchronously from the head broadcaster's callback, so it must return quickly.
+	OnReorg(ctx context.Context, reorg Reorg)
+}
+
+// ReorgBroadcaster follows the longest chain of a head Broadcaster and notifies its subscribers when it switches to
+// another branch, so that they don't have to infer reorgs from the heads themselves.
+type ReorgBroadcaster interface {
+	services.Service
+	Trackable
+	// SubscribeReorgs registers listener until the ReorgBroadcaster is closed or unsubscribe is called.
+	SubscribeReorgs(listener ReorgListener) (unsubscribe func())
+}
+
+type reorgBroadcaster struct {
+	services.Service
+	eng *services.Engine
+
+	chainID         *big.Int
+	headBroadcaster Broadcaster
+	unsubscribe     func()
+
+	mu             sync.Mutex
+	latest         *evmtypes.Head
+	listeners      map[int]ReorgListener
+	lastListenerID int
+}
+
+var _ ReorgBroadcaster = (*reorgBroadcaster)(nil)
+
+// NewReorgBroadcaster creates a ReorgBroadcaster, which subscribes to headBroadcaster when started.
+func NewReorgBroadcaster(lggr logger.Logger, chainID *big.Int, headBroadcaster Broadcaster) ReorgBroadcaster {
+	rb := &reorgBroadcaster{
+		chainID:         chainID,
+		headBroadcaster: headBroadcaster,
+		listeners:       make(map[int]ReorgListener),
+	}
+	rb.Service, rb.eng = services.Config{
+		Name:  "ReorgBroadcaster",
+		Start: rb.start,
+		Close: rb.close,
+	}.NewServiceEngine(lggr)
+	return rb
+}
+
+func (rb *reorgBroadcaster) start(context.Context) error {
+	var latest *evmtypes.Head
+	latest, rb.unsubscribe = rb.headBroadcaster.Subscribe(rb)
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.latest == nil {
+		rb.latest = latest
+	}
+	return nil
+}
+
+func (rb *reorgBroadcaster) close() error {
+	rb.unsubscribe()
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.listeners = make(map[int]ReorgListener)
+	return nil
+}
+
+func (rb *reorgBroadcaster) SubscribeReorgs(listener ReorgListener) (unsubscribe func()) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.lastListenerID++
+	id := rb.lastListenerID
+	rb.listeners[id] = listener
+	return func() {
+		rb.mu.Lock()
+		defer rb.mu.Unlock()
+		delete(rb.listeners, id)
+	}
+}
+
+// OnNewLongestChain compares head with the previous longest chain and notifies the listeners if it is on another branch.
+func (rb *reorgBroadcaster) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	rb.mu.Lock()
+	reorg := findReorg(rb.latest, head)
+	rb.latest = head
+	listeners := make([]ReorgListener, 0, len(rb.listeners))
+	for _, l := range rb.listeners {
+		listeners = append(listeners, l)
+	}
+	rb.mu.Unlock()
+
+	if reorg == nil {
+		return
+	}
+	reorg.ChainID = rb.chainID
+	promReorgDepth.WithLabelValues(rb.chainID.String()).Observe(float64(reorg.Depth))
+	rb.eng.Infow("Reorg detected", "commonAncestor", reorg.CommonAncestor, "commonAncestorNumber", reorg.CommonAncestorNumber,
+		"depth", reorg.Depth, "fromBlock", reorg.FromBlock, "toBlock", reorg.ToBlock, "newHead", head.Hash)
+	for _, l := range listeners {
+		l.OnReorg(ctx, *reorg)
+	}
+}
@@ -0,0 +1,127 @@
+package heads_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/heads"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+type reorgListener struct {
+	mu     sync.Mutex
+	reorgs []heads.Reorg
+}
+
+func (l *reorgListener) OnReorg(_ context.Context, reorg heads.Reorg) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reorgs = append(l.reorgs, reorg)
+}
+
+func (l *reorgListener) received() []heads.Reorg {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.reorgs
+}
+
+func TestReorgBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	chainID := big.NewInt(1337)
+	newHead := func(num int64, parent common.Hash) *evmtypes.Head {
+		h := evmtypes.NewHead(big.NewInt(num), utils.NewHash(), parent, ubig.New(chainID))
+		return &h
+	}
+
+	// H0 <- H1 <- H2 <- H3
+	//         \
+	//           H2' <- H3' <- H4'
+	h0 := newHead(0, utils.NewHash())
+	h1 := newHead(1, h0.Hash)
+	h2 := newHead(2, h1.Hash)
+	h3 := newHead(3, h2.Hash)
+	h2b := newHead(2, h1.Hash)
+	h3b := newHead(3, h2b.Hash)
+	h4b := newHead(4, h3b.Hash)
+	headSet := heads.NewHeadSet()
+	require.NoError(t, headSet.AddHeads(h0, h1, h2, h3, h2b, h3b, h4b))
+
+	hb := heads.NewBroadcaster(logger.Test(t))
+	rb := heads.NewReorgBroadcaster(logger.Test(t), chainID, hb)
+	servicetest.Run(t, hb)
+	servicetest.Run(t, rb)
+
+	listener := &reorgListener{}
+	unsubscribe := rb.SubscribeReorgs(listener)
+
+	ctx := tests.Context(t)
+	rb.OnNewLongestChain(ctx, h2)
+	rb.OnNewLongestChain(ctx, h3)
+	assert.Empty(t, listener.received(), "extending the chain is not a reorg")
+
+	rb.OnNewLongestChain(ctx, h4b)
+	require.Len(t, listener.received(), 1)
+	reorg := listener.received()[0]
+	assert.Equal(t, chainID, reorg.ChainID)
+	assert.Equal(t, h1.Hash, reorg.CommonAncestor)
+	assert.Equal(t, int64(1), reorg.CommonAncestorNumber)
+	assert.Equal(t, []common.Hash{h3.Hash, h2.Hash}, reorg.OldBranch)
+	assert.Equal(t, []common.Hash{h4b.Hash, h3b.Hash, h2b.Hash}, reorg.NewBranch)
+	assert.Equal(t, int64(2), reorg.Depth)
+	assert.Equal(t, int64(2), reorg.FromBlock)
+	assert.Equal(t, int64(3), reorg.ToBlock)
+
+	// a head whose parents aren't tracked can't be compared
+	rb.OnNewLongestChain(ctx, newHead(10, utils.NewHash()))
+	assert.Len(t, listener.received(), 1)
+
+	unsubscribe()
+	rb.OnNewLongestChain(ctx, h3)
+	assert.Len(t, listener.received(), 1)
+}
+
+func TestReorgBroadcaster_UnknownAncestor(t *testing.T) {
+	t.Parallel()
+
+	chainID := big.NewInt(1337)
+	newHead := func(num int64, parent common.Hash) *evmtypes.Head {
+		h := evmtypes.NewHead(big.NewInt(num), utils.NewHash(), parent, ubig.New(chainID))
+		return &h
+	}
+	h5 := newHead(5, utils.NewHash())
+	h6 := newHead(6, h5.Hash)
+	h5b := newHead(5, utils.NewHash())
+	h6b := newHead(6, h5b.Hash)
+	h7b := newHead(7, h6b.Hash)
+	headSet := heads.NewHeadSet()
+	require.NoError(t, headSet.AddHeads(h5, h6, h5b, h6b, h7b))
+
+	rb := heads.NewReorgBroadcaster(logger.Test(t), chainID, heads.NewBroadcaster(logger.Test(t)))
+	listener := &reorgListener{}
+	rb.SubscribeReorgs(listener)
+
+	ctx := tests.Context(t)
+	rb.OnNewLongestChain(ctx, h6)
+	rb.OnNewLongestChain(ctx, h7b)
+	require.Len(t, listener.received(), 1)
+	reorg := listener.received()[0]
+	assert.Equal(t, common.Hash{}, reorg.CommonAncestor)
+	assert.Equal(t, []common.Hash{h6.Hash, h5.Hash}, reorg.OldBranch)
+	assert.Equal(t, []common.Hash{h7b.Hash, h6b.Hash, h5b.Hash}, reorg.NewBranch)
+	assert.Equal(t, int64(2), reorg.Depth)
+	assert.Equal(t, int64(5), reorg.FromBlock)
+	assert.Equal(t, int64(6), reorg.ToBlock)
+}
@@ -0,0 +1,135 @@
+//go:build sqlite
+
+package heads
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+var _ ORM = &SQLiteORM{}
+
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS evm_heads (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	evm_chain_id TEXT NOT NULL,
+	hash BLOB NOT NULL,
+	number INTEGER NOT NULL,
+	parent_hash BLOB NOT NULL,
+	created_at INTEGER NOT NULL,
+	timestamp INTEGER NOT NULL,
+	l1_block_number INTEGER,
+	base_fee_per_gas TEXT,
+	UNIQUE (evm_chain_id, hash)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_evm_heads_evm_chain_id_number ON evm_heads (evm_chain_id, number)`,
+}
+
+// SQLiteORM is an ORM storing heads in an SQLite database, for embedded deployments without Postgres. Timestamps are
+// stored as unix nanoseconds, so it does not depend on the time handling of a particular SQLite driver.
+// It is only built with the sqlite build tag, so that other deployments don't depend on it.
+type SQLiteORM struct {
+	chainID ubig.Big
+	ds      sqlutil.DataSource
+}
+
+// NewSQLiteORM creates an ORM scoped to chainID, creating the evm_heads table if it does not exist yet. ds must be
+// backed by an SQLite driver. This package doesn't import one, so the binary using it has to register the driver
+// itself, e.g. with a blank import of github.com/mattn/go-sqlite3, as the tests do.
+func NewSQLiteORM(ctx context.Context, chainID big.Int, ds sqlutil.DataSource) (*SQLiteORM, error) {
+	for _, stmt := range sqliteSchema {
+		if _, err := ds.ExecContext(ctx, stmt); err != nil {
+			return nil, pkgerrors.Wrap(err, "failed to create SQLite heads schema")
+		}
+	}
+	return &SQLiteORM{
+		chainID: ubig.Big(chainID),
+		ds:      ds,
+	}, nil
+}
+
+type sqliteHead struct {
+	Hash          common.Hash   `db:"hash"`
+	Number        int64         `db:"number"`
+	ParentHash    common.Hash   `db:"parent_hash"`
+	CreatedAt     int64         `db:"created_at"`
+	Timestamp     int64         `db:"timestamp"`
+	L1BlockNumber sql.NullInt64 `db:"l1_block_number"`
+	BaseFeePerGas *assets.Wei   `db:"base_fee_per_gas"`
+}
+
+func (h sqliteHead) head(chainID ubig.Big) *evmtypes.Head {
+	return &evmtypes.Head{
+		Hash:          h.Hash,
+		Number:        h.Number,
+		ParentHash:    h.ParentHash,
+		CreatedAt:     time.Unix(0, h.CreatedAt),
+		Timestamp:     time.Unix(0, h.Timestamp),
+		L1BlockNumber: h.L1BlockNumber,
+		EVMChainID:    &chainID,
+		BaseFeePerGas: h.BaseFeePerGas,
+	}
+}
+
+const sqliteHeadColumns = `hash, number, parent_hash, created_at, timestamp, l1_block_number, base_fee_per_gas`
+
+func (orm *SQLiteORM) IdempotentInsertHead(ctx context.Context, head *evmtypes.Head) error {
+	query := `
+	INSERT INTO evm_heads (evm_chain_id, ` + sqliteHeadColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (evm_chain_id, hash) DO NOTHING`
+	_, err := orm.ds.ExecContext(ctx, query, orm.chainID.String(), head.Hash, head.Number, head.ParentHash,
+		time.Now().UnixNano(), head.Timestamp.UnixNano(), head.L1BlockNumber, head.BaseFeePerGas)
+	return pkgerrors.Wrap(err, "IdempotentInsertHead failed to insert head")
+}
+
+func (orm *SQLiteORM) TrimOldHeads(ctx context.Context, minBlockNumber int64) (err error) {
+	_, err = orm.ds.ExecContext(ctx, `DELETE FROM evm_heads WHERE evm_chain_id = ? AND number < ?`, orm.chainID.String(), minBlockNumber)
+	return err
+}
+
+func (orm *SQLiteORM) LatestHead(ctx context.Context) (head *evmtypes.Head, err error) {
+	var h sqliteHead
+	err = orm.ds.GetContext(ctx, &h, `SELECT `+sqliteHeadColumns+` FROM evm_heads WHERE evm_chain_id = ? ORDER BY number DESC, created_at DESC, id DESC LIMIT 1`, orm.chainID.String())
+	if pkgerrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "LatestHead failed")
+	}
+	return h.head(orm.chainID), nil
+}
+
+func (orm *SQLiteORM) LatestHeads(ctx context.Context, minBlockNumber int64) (heads []*evmtypes.Head, err error) {
+	var rows []sqliteHead
+	err = orm.ds.SelectContext(ctx, &rows, `SELECT `+sqliteHeadColumns+` FROM evm_heads WHERE evm_chain_id = ? AND number >= ? ORDER BY number DESC, created_at DESC, id DESC`, orm.chainID.String(), minBlockNumber)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "LatestHeads failed")
+	}
+	for _, h := range rows {
+		heads = append(heads, h.head(orm.chainID))
+	}
+	return heads, nil
+}
+
+func (orm *SQLiteORM) HeadByHash(ctx context.Context, hash common.Hash) (head *evmtypes.Head, err error) {
+	var h sqliteHead
+	err = orm.ds.GetContext(ctx, &h, `SELECT `+sqliteHeadColumns+` FROM evm_heads WHERE evm_chain_id = ? AND hash = ?`, orm.chainID.String(), hash)
+	if pkgerrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return h.head(orm.chainID), nil
+}
@@ -0,0 +1,194 @@
+//go:build sqlite
+
+package heads_test
+
+import (
+	"database/sql"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/heads"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+func newSQLiteDB(t *testing.T, path string) *sqlx.DB {
+	db, err := sqlx.Open("sqlite3", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+	return db
+}
+
+func newSQLiteORM(t *testing.T, db *sqlx.DB, chainID *big.Int) *heads.SQLiteORM {
+	orm, err := heads.NewSQLiteORM(tests.Context(t), *chainID, db)
+	require.NoError(t, err)
+	return orm
+}
+
+func TestSQLiteORM_IdempotentInsertHead(t *testing.T) {
+	t.Parallel()
+
+	db := newSQLiteDB(t, filepath.Join(t.TempDir(), "heads.db"))
+	orm := newSQLiteORM(t, db, testutils.FixtureChainID)
+
+	// Returns nil when inserting first head
+	head := testutils.Head(0)
+	head.L1BlockNumber = sql.NullInt64{Int64: 42, Valid: true}
+	head.BaseFeePerGas = assets.NewWeiI(1_000_000_007)
+	require.NoError(t, orm.IdempotentInsertHead(tests.Context(t), head))
+
+	// Head is inserted
+	foundHead, err := orm.LatestHead(tests.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash, foundHead.Hash)
+	assert.Equal(t, head.ParentHash, foundHead.ParentHash)
+	assert.True(t, head.Timestamp.Equal(foundHead.Timestamp))
+	assert.Equal(t, head.L1BlockNumber, foundHead.L1BlockNumber)
+	assert.Equal(t, head.BaseFeePerGas, foundHead.BaseFeePerGas)
+	assert.Equal(t, testutils.FixtureChainID, foundHead.EVMChainID.ToInt())
+
+	// Returns nil when inserting same head again
+	require.NoError(t, orm.IdempotentInsertHead(tests.Context(t), head))
+
+	// Head is still inserted once
+	heads, err := orm.LatestHeads(tests.Context(t), 0)
+	require.NoError(t, err)
+	require.Len(t, heads, 1)
+}
+
+func TestSQLiteORM_TrimOldHeads(t *testing.T) {
+	t.Parallel()
+
+	db := newSQLiteDB(t, filepath.Join(t.TempDir(), "heads.db"))
+	orm := newSQLiteORM(t, db, testutils.FixtureChainID)
+
+	for i := 0; i < 10; i++ {
+		head := testutils.Head(i)
+		require.NoError(t, orm.IdempotentInsertHead(tests.Context(t), head))
+	}
+
+	uncleHead := testutils.Head(5)
+	require.NoError(t, orm.IdempotentInsertHead(tests.Context(t), uncleHead))
+
+	err := orm.TrimOldHeads(tests.Context(t), 5)
+	require.NoError(t, err)
+
+	heads, err := orm.LatestHeads(tests.Context(t), 0)
+	require.NoError(t, err)
+
+	// uncle block was loaded too
+	require.Len(t, heads, 6)
+	for i := 0; i < 6; i++ {
+		require.LessOrEqual(t, int64(5), heads[i].Number)
+	}
+	assert.Equal(t, int64(9), heads[0].Number)
+	assert.Nil(t, heads[0].BaseFeePerGas)
+	assert.False(t, heads[0].L1BlockNumber.Valid)
+}
+
+func TestSQLiteORM_HeadByHash(t *testing.T) {
+	t.Parallel()
+
+	db := newSQLiteDB(t, filepath.Join(t.TempDir(), "heads.db"))
+	orm := newSQLiteORM(t, db, testutils.FixtureChainID)
+
+	var hash common.Hash
+	for i := 0; i < 10; i++ {
+		head := testutils.Head(i)
+		if i == 5 {
+			hash = head.Hash
+		}
+		require.NoError(t, orm.IdempotentInsertHead(tests.Context(t), head))
+	}
+
+	head, err := orm.HeadByHash(tests.Context(t), hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, head.Hash)
+	require.Equal(t, int64(5), head.Number)
+}
+
+func TestSQLiteORM_HeadByHash_NotFound(t *testing.T) {
+	t.Parallel()
+
+	db := newSQLiteDB(t, filepath.Join(t.TempDir(), "heads.db"))
+	orm := newSQLiteORM(t, db, testutils.FixtureChainID)
+
+	hash := testutils.Head(123).Hash
+	head, err := orm.HeadByHash(tests.Context(t), hash)
+
+	require.Nil(t, head)
+	require.NoError(t, err)
+}
+
+func TestSQLiteORM_LatestHeads_NoRows(t *testing.T) {
+	t.Parallel()
+
+	db := newSQLiteDB(t, filepath.Join(t.TempDir(), "heads.db"))
+	orm := newSQLiteORM(t, db, testutils.FixtureChainID)
+
+	heads, err := orm.LatestHeads(tests.Context(t), 100)
+	require.Empty(t, heads)
+	require.NoError(t, err)
+
+	head, err := orm.LatestHead(tests.Context(t))
+	require.Nil(t, head)
+	require.NoError(t, err)
+}
+
+func TestSQLiteORM_ScopedToChain(t *testing.T) {
+	t.Parallel()
+
+	db := newSQLiteDB(t, filepath.Join(t.TempDir(), "heads.db"))
+	orm := newSQLiteORM(t, db, testutils.FixtureChainID)
+	otherOrm := newSQLiteORM(t, db, big.NewInt(1337))
+
+	head := testutils.Head(1)
+	require.NoError(t, orm.IdempotentInsertHead(tests.Context(t), head))
+	// the same hash may be stored for another chain
+	require.NoError(t, otherOrm.IdempotentInsertHead(tests.Context(t), head))
+	require.NoError(t, otherOrm.TrimOldHeads(tests.Context(t), 2))
+
+	found, err := orm.HeadByHash(tests.Context(t), head.Hash)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+
+	found, err = otherOrm.HeadByHash(tests.Context(t), head.Hash)
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func TestSQLiteORM_SaverSurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "heads.db")
+	htCfg := &config{finalityDepth: uint32(1)}
+	trackerCfg := &trackerConfig{historyDepth: 6}
+
+	saver := heads.NewSaver(logger.Test(t), newSQLiteORM(t, newSQLiteDB(t, path), testutils.FixtureChainID), htCfg, trackerCfg)
+	h0 := testutils.Head(0)
+	h1 := testutils.Head(1)
+	h1.ParentHash = h0.Hash
+	h0.Timestamp = time.Unix(1700000000, 0)
+	require.NoError(t, saver.Save(tests.Context(t), h0))
+	require.NoError(t, saver.Save(tests.Context(t), h1))
+
+	// a new process opening the same database loads the saved chain
+	saver = heads.NewSaver(logger.Test(t), newSQLiteORM(t, newSQLiteDB(t, path), testutils.FixtureChainID), htCfg, trackerCfg)
+	latest, err := saver.Load(tests.Context(t), 0)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, h1.Hash, latest.Hash)
+	assert.Equal(t, uint32(2), latest.ChainLength())
+	assert.True(t, h0.Timestamp.Equal(latest.Parent.Load().Timestamp))
+}
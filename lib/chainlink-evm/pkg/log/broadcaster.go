@@ -144,6 +144,13 @@ type (
 
 		// ReplayStartedCallback is called by the log broadcaster once a replay request is received.
 		ReplayStartedCallback func()
+
+		// QueueSize, if set, makes the broadcaster deliver logs to the listener from a queue of this size with its own
+		// goroutine, so that a slow listener doesn't delay the others. Otherwise HandleLog is called synchronously with
+		// the other listeners on every head.
+		QueueSize uint32
+		// OverflowPolicy decides what happens to a log sent while the queue is full. It is ignored without QueueSize.
+		OverflowPolicy OverflowPolicy
 	}
 
 	ParseLogFunc func(log types.Log) (generated.AbigenLog, error)
@@ -168,7 +175,7 @@ func NewBroadcaster(orm ORM, ethClient evmclient.Client, config Config, lggr log
 	chStop := make(chan struct{})
 	lggr = logger.Named(lggr, "LogBroadcaster")
 	chainId := ethClient.ConfiguredChainID()
-	return &broadcaster{
+	b := &broadcaster{
 		orm:                    orm,
 		config:                 config,
 		logger:                 lggr,
@@ -184,6 +191,7 @@ func NewBroadcaster(orm ORM, ethClient evmclient.Client, config Config, lggr log
 		highestSavedHeadFn:     highestSavedHead,
 		replayChannel:          make(chan replayRequest, 1),
 	}
+	return b
 }
 
 func (b *broadcaster) Start(context.Context) error {
@@ -211,6 +219,7 @@ func (b *broadcaster) Close() error {
 	return b.StopOnce("LogBroadcaster", func() error {
 		close(b.chStop)
 		b.wgDone.Wait()
+		b.registrations.close()
 		return b.changeSubscriberStatus.Close()
 	})
 }
@@ -643,6 +652,38 @@ func (b *broadcaster) onNewHeads() {
 				b.logger.Errorw("Failed to set pending broadcasts number", "blockNumber", keptDepth, "err", err)
 			}
 		}
+
+		b.replayListeners(ctx, latestHead)
+	}
+}
+
+// replayListeners sends the logs dropped by full listener queues again, to their listener only.
+func (b *broadcaster) replayListeners(ctx context.Context, latestHead *evmtypes.Head) {
+	for sub, from := range b.registrations.takeReplays() {
+		chBackfilledLogs, abort := b.ethSubscriber.backfillLogs(sql.NullInt64{Int64: from, Valid: true}, []common.Address{sub.opts.Contract}, subscriberTopics(sub))
+		if abort {
+			return
+		}
+		if chBackfilledLogs == nil {
+			b.logger.Errorw("Failed to fetch logs to replay", "fromBlock", from, "jobID", sub.listener.JobID())
+			b.registrations.requestReplay(sub, from)
+			continue
+		}
+		var logs []types.Log
+		for log := range chBackfilledLogs {
+			logs = append(logs, log)
+		}
+		if len(logs) == 0 {
+			continue
+		}
+		broadcasts, err := b.orm.FindBroadcasts(ctx, from, latestHead.Number)
+		if err != nil {
+			b.logger.Errorf("Failed to query for log broadcasts, %v", err)
+			b.registrations.requestReplay(sub, from)
+			continue
+		}
+		b.logger.Infow("Replaying dropped logs to listener", "fromBlock", from, "jobID", sub.listener.JobID())
+		b.registrations.sendLogsTo(ctx, sub, groupLogsByBlock(logs), latestHead, broadcasts, b.orm)
 	}
 }
 
@@ -0,0 +1,227 @@
+package log
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+var (
+	promListenerQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_broadcaster_listener_queue_depth",
+		Help: "Number of logs waiting in the queue of a log broadcaster listener",
+	}, []string{"evmChainID", "jobID", "contract"})
+	promListenerDroppedLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_broadcaster_listener_dropped_logs",
+		Help: "Number of logs dropped because the queue of a log broadcaster listener was full",
+	}, []string{"evmChainID", "jobID", "contract"})
+	promListenerHandleLogDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "log_broadcaster_listener_handle_log_seconds",
+		Help:    "Time taken by a log broadcaster listener to handle a log",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60},
+	}, []string{"evmChainID", "jobID", "contract"})
+)
+
+// OverflowPolicy decides what happens to a log sent to a listener whose queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the listener made room in its queue. This delays the delivery to all other listeners.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest queued log to make room. Like any unconsumed log, the dropped log is sent
+	// again on the next heads while it is still within the lookback of the broadcaster.
+	OverflowDropOldest
+	// OverflowReplay drops the new log and logs an error. Once the listener handled its queue, the logs from the
+	// block of the earliest dropped log are read again and sent to this listener only.
+	OverflowReplay
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "Block"
+	case OverflowDropOldest:
+		return "DropOldest"
+	case OverflowReplay:
+		return "Replay"
+	default:
+		return "OverflowPolicy(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// listenerLabels identifies the series of a listener, since a job has at most one listener per contract.
+func listenerLabels(evmChainID big.Int, jobID int32, contract common.Address) []string {
+	return []string{evmChainID.String(), strconv.Itoa(int(jobID)), contract.Hex()}
+}
+
+func observeHandleLog(evmChainID big.Int, jobID int32, contract common.Address, start time.Time) {
+	promListenerHandleLogDuration.WithLabelValues(listenerLabels(evmChainID, jobID, contract)...).Observe(time.Since(start).Seconds())
+}
+
+// deleteListenerMetrics removes the series of a listener once it is unsubscribed.
+func deleteListenerMetrics(evmChainID big.Int, jobID int32, contract common.Address) {
+	labels := listenerLabels(evmChainID, jobID, contract)
+	promListenerQueueDepth.DeleteLabelValues(labels...)
+	promListenerDroppedLogs.DeleteLabelValues(labels...)
+	promListenerHandleLogDuration.DeleteLabelValues(labels...)
+}
+
+// listenerQueue delivers logs to a single listener from its own goroutine, so that a slow listener doesn't delay the
+// others. A log which is still queued or being handled is not queued again, since the broadcaster sends unconsumed
+// logs again on every head.
+type listenerQueue struct {
+	listener   Listener
+	contract   common.Address
+	evmChainID big.Int
+	size       int
+	policy     OverflowPolicy
+
+	stopCh  services.StopChan
+	wg      sync.WaitGroup
+	chItems chan struct{}
+	chSpace chan struct{}
+
+	mu      sync.Mutex
+	items   []Broadcast
+	pending map[LogBroadcastAsKey]struct{}
+}
+
+func newListenerQueue(listener Listener, contract common.Address, evmChainID big.Int, size uint32, policy OverflowPolicy) *listenerQueue {
+	q := &listenerQueue{
+		listener:   listener,
+		contract:   contract,
+		evmChainID: evmChainID,
+		size:       int(size),
+		policy:     policy,
+		stopCh:     make(services.StopChan),
+		chItems:    make(chan struct{}, 1),
+		chSpace:    make(chan struct{}, 1),
+		pending:    make(map[LogBroadcastAsKey]struct{}),
+	}
+	q.wg.Add(1)
+	go q.run()
+	return q
+}
+
+func broadcastKey(b Broadcast) LogBroadcastAsKey {
+	return LogBroadcastAsKey{BlockHash: b.RawLog().BlockHash, LogIndex: b.RawLog().Index, JobId: b.JobID()}
+}
+
+func (q *listenerQueue) labels() []string {
+	return listenerLabels(q.evmChainID, q.listener.JobID(), q.contract)
+}
+
+// enqueue adds b to the queue, applying the overflow policy if it is full. It returns false if b was dropped and must
+// be replayed to the listener.
+func (q *listenerQueue) enqueue(ctx context.Context, b Broadcast) bool {
+	key := broadcastKey(b)
+	for {
+		q.mu.Lock()
+		if _, exists := q.pending[key]; exists {
+			q.mu.Unlock()
+			return true
+		}
+		if len(q.items) >= q.size {
+			switch q.policy {
+			case OverflowDropOldest:
+				delete(q.pending, broadcastKey(q.items[0]))
+				q.items[0] = nil
+				q.items = q.items[1:]
+				promListenerDroppedLogs.WithLabelValues(q.labels()...).Inc()
+			case OverflowReplay:
+				q.mu.Unlock()
+				promListenerDroppedLogs.WithLabelValues(q.labels()...).Inc()
+				return false
+			default:
+				q.mu.Unlock()
+				select {
+				case <-q.chSpace:
+					continue
+				case <-ctx.Done():
+					return true
+				case <-q.stopCh:
+					return true
+				}
+			}
+		}
+		q.items = append(q.items, b)
+		q.pending[key] = struct{}{}
+		promListenerQueueDepth.WithLabelValues(q.labels()...).Set(float64(len(q.items)))
+		q.mu.Unlock()
+
+		select {
+		case q.chItems <- struct{}{}:
+		default:
+		}
+		return true
+	}
+}
+
+// idle returns true if the listener handled all logs sent to the queue.
+func (q *listenerQueue) idle() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending) == 0
+}
+
+func (q *listenerQueue) next() (Broadcast, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	b := q.items[0]
+	q.items[0] = nil
+	q.items = q.items[1:]
+	promListenerQueueDepth.WithLabelValues(q.labels()...).Set(float64(len(q.items)))
+	return b, true
+}
+
+func (q *listenerQueue) run() {
+	defer q.wg.Done()
+	ctx, cancel := q.stopCh.NewCtx()
+	defer cancel()
+	for {
+		select {
+		case <-q.stopCh:
+			return
+		case <-q.chItems:
+		}
+
+		for {
+			b, ok := q.next()
+			if !ok {
+				break
+			}
+			select {
+			case q.chSpace <- struct{}{}:
+			default:
+			}
+
+			start := time.Now()
+			q.listener.HandleLog(ctx, b)
+			observeHandleLog(q.evmChainID, b.JobID(), q.contract, start)
+
+			q.mu.Lock()
+			delete(q.pending, broadcastKey(b))
+			q.mu.Unlock()
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// close stops the delivery, dropping the queued logs, and waits for the listener to return from HandleLog.
+func (q *listenerQueue) close() {
+	close(q.stopCh)
+	q.wg.Wait()
+}
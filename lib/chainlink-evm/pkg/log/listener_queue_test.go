@@ -0,0 +1,267 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+)
+
+// gatedListener reports every log it starts handling and then waits until it is released.
+type gatedListener struct {
+	jobID    int32
+	received chan Broadcast
+	release  chan struct{}
+}
+
+func newGatedListener(jobID int32) *gatedListener {
+	return &gatedListener{jobID: jobID, received: make(chan Broadcast, 10), release: make(chan struct{}, 10)}
+}
+
+func (l *gatedListener) JobID() int32 { return l.jobID }
+
+func (l *gatedListener) HandleLog(ctx context.Context, b Broadcast) {
+	l.received <- b
+	select {
+	case <-l.release:
+	case <-ctx.Done():
+	}
+}
+
+func (l *gatedListener) awaitLog(t *testing.T) Broadcast {
+	select {
+	case b := <-l.received:
+		return b
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for log")
+		return nil
+	}
+}
+
+func newTestBroadcast(index uint) Broadcast {
+	return NewLogBroadcast(types.Log{BlockHash: utils.NewHash(), BlockNumber: uint64(index), Index: index}, *testutils.FixtureChainID, nil)
+}
+
+func TestListenerQueue_Overflow(t *testing.T) {
+	t.Run("drop oldest", func(t *testing.T) {
+		l := newGatedListener(1)
+		q := newListenerQueue(l, common.Address{}, *testutils.FixtureChainID, 1, OverflowDropOldest)
+		t.Cleanup(q.close)
+
+		b1, b2, b3 := newTestBroadcast(1), newTestBroadcast(2), newTestBroadcast(3)
+		require.True(t, q.enqueue(testutils.Context(t), b1))
+		assert.Equal(t, b1, l.awaitLog(t))
+
+		// b1 is still being handled, so it isn't queued again
+		require.True(t, q.enqueue(testutils.Context(t), b1))
+		require.True(t, q.enqueue(testutils.Context(t), b2))
+		require.True(t, q.enqueue(testutils.Context(t), b3))
+
+		l.release <- struct{}{}
+		assert.Equal(t, b3, l.awaitLog(t))
+		l.release <- struct{}{}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		l := newGatedListener(1)
+		q := newListenerQueue(l, common.Address{}, *testutils.FixtureChainID, 1, OverflowReplay)
+		t.Cleanup(q.close)
+
+		b1, b2, b3 := newTestBroadcast(1), newTestBroadcast(2), newTestBroadcast(3)
+		require.True(t, q.enqueue(testutils.Context(t), b1))
+		assert.Equal(t, b1, l.awaitLog(t))
+		require.True(t, q.enqueue(testutils.Context(t), b2))
+		require.False(t, q.enqueue(testutils.Context(t), b3))
+
+		l.release <- struct{}{}
+		assert.Equal(t, b2, l.awaitLog(t))
+		l.release <- struct{}{}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		l := newGatedListener(1)
+		q := newListenerQueue(l, common.Address{}, *testutils.FixtureChainID, 1, OverflowBlock)
+		t.Cleanup(q.close)
+
+		b1, b2, b3 := newTestBroadcast(1), newTestBroadcast(2), newTestBroadcast(3)
+		require.True(t, q.enqueue(testutils.Context(t), b1))
+		assert.Equal(t, b1, l.awaitLog(t))
+		require.True(t, q.enqueue(testutils.Context(t), b2))
+
+		enqueued := make(chan bool)
+		go func() { enqueued <- q.enqueue(testutils.Context(t), b3) }()
+		select {
+		case <-enqueued:
+			t.Fatal("expected enqueue to block while the queue is full")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		l.release <- struct{}{}
+		assert.Equal(t, b2, l.awaitLog(t))
+		assert.True(t, <-enqueued)
+		l.release <- struct{}{}
+		assert.Equal(t, b3, l.awaitLog(t))
+		l.release <- struct{}{}
+	})
+}
+
+func TestRegistrations_sendLogs_QueuedListenerDoesNotBlockOthers(t *testing.T) {
+	ctx := testutils.Context(t)
+	r := newTestRegistrations(t)
+	t.Cleanup(r.close)
+
+	contract := testutils.NewAddress()
+	topic := common.HexToHash("0x01")
+	parseLog := func(types.Log) (generated.AbigenLog, error) { return testLog{}, nil }
+
+	slow := newGatedListener(1)
+	r.addSubscriber(&subscriber{slow, ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]Topic{topic: {}},
+		ParseLog:                 parseLog,
+		MinIncomingConfirmations: 1,
+		QueueSize:                1,
+		OverflowPolicy:           OverflowDropOldest,
+	}})
+	fast := &chanListener{jobID: 2, logs: make(chan Broadcast, 10)}
+	r.addSubscriber(&subscriber{fast, ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]Topic{topic: {}},
+		ParseLog:                 parseLog,
+		MinIncomingConfirmations: 1,
+	}})
+
+	orm := &fakeBroadcastsORM{consumed: make(map[LogBroadcastAsKey]bool)}
+	sendLog := func(blockNumber uint64) {
+		rawLog := types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: blockNumber, BlockHash: utils.NewHash()}
+		r.sendLogs(ctx, []logsOnBlock{{BlockNumber: blockNumber, Logs: []types.Log{rawLog}}}, &evmtypes.Head{Number: int64(blockNumber)}, nil, orm)
+	}
+	sendLog(1)
+	assert.Equal(t, uint64(1), slow.awaitLog(t).RawLog().BlockNumber)
+
+	// the slow listener is stuck handling the first log, while the others are delivered to the fast one
+	sendLog(2)
+	sendLog(3)
+	require.Len(t, fast.logs, 3)
+	slow.release <- struct{}{}
+	assert.Equal(t, uint64(3), slow.awaitLog(t).RawLog().BlockNumber)
+	slow.release <- struct{}{}
+}
+
+func TestRegistrations_sendLogsTo_ReplaysDroppedLogsToListener(t *testing.T) {
+	ctx := testutils.Context(t)
+	r := newTestRegistrations(t)
+	t.Cleanup(r.close)
+
+	contract := testutils.NewAddress()
+	topic := common.HexToHash("0x01")
+	parseLog := func(types.Log) (generated.AbigenLog, error) { return testLog{}, nil }
+
+	slow := newGatedListener(1)
+	slowSub := &subscriber{slow, ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]Topic{topic: {}},
+		ParseLog:                 parseLog,
+		MinIncomingConfirmations: 1,
+		QueueSize:                1,
+		OverflowPolicy:           OverflowReplay,
+	}}
+	r.addSubscriber(slowSub)
+	fast := &chanListener{jobID: 2, logs: make(chan Broadcast, 10)}
+	r.addSubscriber(&subscriber{fast, ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]Topic{topic: {}},
+		ParseLog:                 parseLog,
+		MinIncomingConfirmations: 1,
+	}})
+
+	orm := &fakeBroadcastsORM{consumed: make(map[LogBroadcastAsKey]bool)}
+	rawLogs := make(map[uint64]types.Log)
+	sendLog := func(blockNumber uint64) {
+		rawLogs[blockNumber] = types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: blockNumber, BlockHash: utils.NewHash()}
+		r.sendLogs(ctx, []logsOnBlock{{BlockNumber: blockNumber, Logs: []types.Log{rawLogs[blockNumber]}}}, &evmtypes.Head{Number: int64(blockNumber)}, nil, orm)
+	}
+	sendLog(1)
+	assert.Equal(t, uint64(1), slow.awaitLog(t).RawLog().BlockNumber)
+
+	// the third log doesn't fit in the queue of the slow listener, and is only replayed once it handled the others
+	sendLog(2)
+	sendLog(3)
+	slow.release <- struct{}{}
+	assert.Equal(t, uint64(2), slow.awaitLog(t).RawLog().BlockNumber)
+	assert.Empty(t, r.takeReplays())
+	slow.release <- struct{}{}
+
+	var replays map[*subscriber]int64
+	require.Eventually(t, func() bool {
+		replays = r.takeReplays()
+		return len(replays) > 0
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+	assert.Equal(t, map[*subscriber]int64{slowSub: 3}, replays)
+	assert.Empty(t, r.takeReplays())
+
+	r.sendLogsTo(ctx, slowSub, groupLogsByBlock([]types.Log{rawLogs[3]}), &evmtypes.Head{Number: 3}, nil, orm)
+	assert.Equal(t, uint64(3), slow.awaitLog(t).RawLog().BlockNumber)
+	slow.release <- struct{}{}
+	require.Len(t, fast.logs, 3)
+}
+
+func TestRegistrations_removeSubscriber_DeletesListenerMetrics(t *testing.T) {
+	ctx := testutils.Context(t)
+	r := newTestRegistrations(t)
+	t.Cleanup(r.close)
+
+	topic := common.HexToHash("0x01")
+	parseLog := func(types.Log) (generated.AbigenLog, error) { return testLog{}, nil }
+	orm := &fakeBroadcastsORM{consumed: make(map[LogBroadcastAsKey]bool)}
+
+	// both listeners belong to the same job, and only the removed one must lose its series
+	var subs []*subscriber
+	for range 2 {
+		contract := testutils.NewAddress()
+		l := newGatedListener(1)
+		sub := &subscriber{l, ListenerOpts{
+			Contract:                 contract,
+			LogsWithTopics:           map[common.Hash][][]Topic{topic: {}},
+			ParseLog:                 parseLog,
+			MinIncomingConfirmations: 1,
+			QueueSize:                1,
+			OverflowPolicy:           OverflowDropOldest,
+		}}
+		r.addSubscriber(sub)
+		subs = append(subs, sub)
+
+		for blockNumber := uint64(1); blockNumber <= 3; blockNumber++ {
+			rawLog := types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: blockNumber, BlockHash: utils.NewHash()}
+			r.sendLogs(ctx, []logsOnBlock{{BlockNumber: blockNumber, Logs: []types.Log{rawLog}}}, &evmtypes.Head{Number: int64(blockNumber)}, nil, orm)
+			if blockNumber == 1 {
+				l.awaitLog(t)
+			}
+		}
+		l.release <- struct{}{}
+		l.awaitLog(t)
+		l.release <- struct{}{}
+		require.Eventually(t, r.queues[sub].idle, testutils.WaitTimeout(t), 10*time.Millisecond)
+	}
+
+	labels := func(sub *subscriber) []string {
+		return listenerLabels(*testutils.FixtureChainID, sub.listener.JobID(), sub.opts.Contract)
+	}
+	r.removeSubscriber(subs[1])
+	assert.False(t, promListenerQueueDepth.DeleteLabelValues(labels(subs[1])...))
+	assert.False(t, promListenerDroppedLogs.DeleteLabelValues(labels(subs[1])...))
+	assert.False(t, promListenerHandleLogDuration.DeleteLabelValues(labels(subs[1])...))
+
+	assert.True(t, promListenerQueueDepth.DeleteLabelValues(labels(subs[0])...))
+	assert.True(t, promListenerDroppedLogs.DeleteLabelValues(labels(subs[0])...))
+	assert.True(t, promListenerHandleLogDuration.DeleteLabelValues(labels(subs[0])...))
+}
@@ -0,0 +1,383 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/mailbox"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+// logPollerBroadcaster is a Broadcaster which reads logs from a LogPoller instead of subscribing to them over a
+// websocket connection. Every listener gets its own LogPoller filter, and on every new head the logs of the last
+// max(FinalityDepth, highest MinIncomingConfirmations) blocks indexed by the LogPoller are sent to the listeners
+// for which they have enough confirmations, skipping those already consumed, like the websocket based broadcaster
+// does with its pool.
+//
+// Logs emitted before a listener registered are only delivered if the LogPoller indexed them, so the LogPoller must
+// be replayed (e.g. via ReplayFromBlock) to pick up the history of a new filter.
+type logPollerBroadcaster struct {
+	services.Service
+	eng *services.Engine
+	utils.DependentAwaiter
+
+	orm    ORM
+	lp     logpoller.LogPoller
+	config Config
+
+	// registrations, filters, lastFilterID, backfillFrom and initialBackfillDone are only accessed from the run loop
+	registrations       *registrations
+	filters             map[*subscriber]logpoller.Filter
+	failedFilters       map[*subscriber]struct{}
+	lastFilterID        int64
+	backfillFrom        *int64
+	initialBackfillDone bool
+
+	changeSubscriberStatus *mailbox.Mailbox[changeSubscriberStatus]
+	newHeads               *mailbox.Mailbox[*evmtypes.Head]
+	replayChannel          chan replayRequest
+}
+
+var _ Broadcaster = (*logPollerBroadcaster)(nil)
+
+// NewLogPollerBroadcaster creates a Broadcaster which sources logs from lp, so that listeners can run on chains
+// without a websocket endpoint. Like the websocket based broadcaster, it must be subscribed to a head broadcaster and
+// waits for its dependents before sending any logs.
+func NewLogPollerBroadcaster(orm ORM, lp logpoller.LogPoller, config Config, lggr logger.Logger, evmChainID big.Int) Broadcaster {
+	b := &logPollerBroadcaster{
+		DependentAwaiter:       utils.NewDependentAwaiter(),
+		orm:                    orm,
+		lp:                     lp,
+		config:                 config,
+		registrations:          newRegistrations(lggr, evmChainID),
+		filters:                make(map[*subscriber]logpoller.Filter),
+		failedFilters:          make(map[*subscriber]struct{}),
+		changeSubscriberStatus: mailbox.NewHighCapacity[changeSubscriberStatus](),
+		newHeads:               mailbox.NewSingle[*evmtypes.Head](),
+		replayChannel:          make(chan replayRequest, 1),
+	}
+	b.Service, b.eng = services.Config{
+		Name:  "LogPollerBroadcaster",
+		Start: b.start,
+		Close: b.close,
+	}.NewServiceEngine(lggr)
+	return b
+}
+
+func (b *logPollerBroadcaster) start(context.Context) error {
+	b.eng.Go(b.run)
+	return nil
+}
+
+func (b *logPollerBroadcaster) close() error {
+	b.registrations.close()
+	return b.changeSubscriberStatus.Close()
+}
+
+func (b *logPollerBroadcaster) run(ctx context.Context) {
+	select {
+	case <-b.AwaitDependents():
+	case <-ctx.Done():
+		return
+	}
+
+	pendingMin, err := b.orm.Reinitialize(ctx)
+	if err != nil {
+		b.eng.Errorw("Failed to reinitialize log broadcasts", "err", err)
+	} else if pendingMin != nil {
+		b.backfillFrom = pendingMin
+		b.eng.Debugw("Backfilling from the earliest pending broadcast", "blockNumber", *pendingMin)
+	}
+	// Add the listeners registered while waiting before the first head is processed.
+	b.onChangeSubscriberStatus(ctx)
+
+	for {
+		// Replay requests take priority.
+		select {
+		case req := <-b.replayChannel:
+			b.onReplayRequest(ctx, req)
+			continue
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-b.replayChannel:
+			b.onReplayRequest(ctx, req)
+		case <-b.changeSubscriberStatus.Notify():
+			b.onChangeSubscriberStatus(ctx)
+		case <-b.newHeads.Notify():
+			b.onNewHeads(ctx)
+		}
+	}
+}
+
+// ReplayFromBlock implements the Broadcaster interface. The LogPoller is replayed as well, so that logs of filters
+// registered after the blocks were indexed are delivered too.
+func (b *logPollerBroadcaster) ReplayFromBlock(number int64, forceBroadcast bool) {
+	b.eng.Infow("Replay requested", "block number", number, "force", forceBroadcast)
+	select {
+	case b.replayChannel <- replayRequest{
+		fromBlock:      number,
+		forceBroadcast: forceBroadcast,
+	}:
+	default:
+	}
+}
+
+func (b *logPollerBroadcaster) onReplayRequest(ctx context.Context, req replayRequest) {
+	for sub := range b.registrations.registeredSubs {
+		if sub.opts.ReplayStartedCallback != nil {
+			sub.opts.ReplayStartedCallback()
+		}
+	}
+
+	if req.forceBroadcast {
+		// Use a longer timeout in the event that a very large amount of logs need to be marked
+		// as unconsumed.
+		markCtx, cancel := context.WithTimeout(sqlutil.WithoutDefaultTimeout(ctx), time.Minute)
+		defer cancel()
+		if err := b.orm.MarkBroadcastsUnconsumed(markCtx, req.fromBlock); err != nil {
+			b.eng.Errorw("Error marking broadcasts as unconsumed", "err", err, "fromBlock", req.fromBlock)
+		}
+	}
+	b.backfillFrom = &req.fromBlock
+	b.lp.ReplayAsync(req.fromBlock)
+}
+
+// IsConnected always returns true, since logs are read from the database rather than a subscription.
+func (b *logPollerBroadcaster) IsConnected() bool {
+	return true
+}
+
+func (b *logPollerBroadcaster) Register(listener Listener, opts ListenerOpts) (unsubscribe func()) {
+	err := b.eng.IfNotStopped(func() error {
+		if len(opts.LogsWithTopics) == 0 {
+			b.eng.Panic("Must supply at least 1 LogsWithTopics element to Register")
+		}
+		if opts.MinIncomingConfirmations <= 0 {
+			b.eng.Warnw(fmt.Sprintf("LogBroadcaster requires that MinIncomingConfirmations must be at least 1 (got %v). Logs must have been confirmed in at least 1 block, it does not support reading logs from the mempool before they have been mined. MinIncomingConfirmations will be set to 1.", opts.MinIncomingConfirmations), "addr", opts.Contract.Hex(), "jobID", listener.JobID())
+			opts.MinIncomingConfirmations = 1
+		}
+
+		sub := &subscriber{listener, opts}
+		b.eng.Debugf("Registering subscriber %p with job ID %v", sub, sub.listener.JobID())
+		if b.changeSubscriberStatus.Deliver(changeSubscriberStatus{subscriberStatusSubscribe, sub}) {
+			b.eng.Panicf("LogBroadcaster subscribe: cannot subscribe %p with job ID %v; changeSubscriberStatus channel was full", sub, sub.listener.JobID())
+		}
+
+		unsubscribe = func() {
+			b.eng.Debugf("Unregistering subscriber %p with job ID %v", sub, sub.listener.JobID())
+			if b.changeSubscriberStatus.Deliver(changeSubscriberStatus{subscriberStatusUnsubscribe, sub}) {
+				b.eng.Panicf("LogBroadcaster unsubscribe: cannot unsubscribe %p with job ID %v; changeSubscriberStatus channel was full", sub, sub.listener.JobID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		b.eng.Panic("Register cannot be called on a stopped log broadcaster (this is an invariant violation because all dependent services should have unregistered themselves before logbroadcaster.Close was called)")
+	}
+	return
+}
+
+// filterFor returns the LogPoller filter matching all events sub listens to. The topic value filters are applied
+// when sending, since they are per field position rather than per topic. id makes the filter name unique, since a
+// job may register several listeners for the same contract, and unregistering one must not remove the filter of another.
+func filterFor(sub *subscriber, id int64) logpoller.Filter {
+	eventSigs := make(evmtypes.HashArray, 0, len(sub.opts.LogsWithTopics))
+	for topic := range sub.opts.LogsWithTopics {
+		eventSigs = append(eventSigs, topic)
+	}
+	return logpoller.Filter{
+		Name:      logpoller.FilterName("LogBroadcaster", strconv.Itoa(int(sub.listener.JobID())), sub.opts.Contract, strconv.FormatInt(id, 10)),
+		Addresses: evmtypes.AddressArray{sub.opts.Contract},
+		EventSigs: eventSigs,
+	}
+}
+
+func (b *logPollerBroadcaster) onChangeSubscriberStatus(ctx context.Context) {
+	for {
+		change, exists := b.changeSubscriberStatus.Retrieve()
+		if !exists {
+			return
+		}
+		sub := change.sub
+
+		if change.newStatus == subscriberStatusSubscribe {
+			b.eng.Debugw("Subscribing listener", "requiredBlockConfirmations", sub.opts.MinIncomingConfirmations, "address", sub.opts.Contract, "jobID", sub.listener.JobID())
+			b.registrations.addSubscriber(sub)
+			b.lastFilterID++
+			b.filters[sub] = filterFor(sub, b.lastFilterID)
+			b.registerFilter(ctx, sub)
+		} else {
+			b.eng.Debugw("Unsubscribing listener", "requiredBlockConfirmations", sub.opts.MinIncomingConfirmations, "address", sub.opts.Contract, "jobID", sub.listener.JobID())
+			b.registrations.removeSubscriber(sub)
+			filter := b.filters[sub]
+			delete(b.filters, sub)
+			delete(b.failedFilters, sub)
+			if err := b.lp.UnregisterFilter(ctx, filter.Name); err != nil {
+				b.eng.Errorw("Failed to unregister LogPoller filter", "filter", filter.Name, "err", err)
+			}
+		}
+	}
+}
+
+// registerFilter registers the LogPoller filter of sub, or remembers it to be retried on the next head.
+func (b *logPollerBroadcaster) registerFilter(ctx context.Context, sub *subscriber) {
+	filter := b.filters[sub]
+	if err := b.lp.RegisterFilter(ctx, filter); err != nil {
+		b.eng.Errorw("Failed to register LogPoller filter, will retry on the next head", "filter", filter.Name, "err", err)
+		b.failedFilters[sub] = struct{}{}
+		return
+	}
+	delete(b.failedFilters, sub)
+}
+
+func (b *logPollerBroadcaster) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	if b.newHeads.Deliver(head) {
+		b.eng.Debugw("Dropped the older head in the mailbox, while inserting latest (which is fine)", "latestBlockNumber", head.Number)
+	}
+}
+
+func (b *logPollerBroadcaster) onNewHeads(ctx context.Context) {
+	var latestHead *evmtypes.Head
+	for {
+		// We only care about the most recent head
+		head := b.newHeads.RetrieveLatestAndClear()
+		if head == nil {
+			break
+		}
+		latestHead = head
+	}
+	if latestHead == nil {
+		return
+	}
+
+	for sub := range b.failedFilters {
+		b.registerFilter(ctx, sub)
+	}
+	if len(b.registrations.registeredSubs) == 0 {
+		return
+	}
+
+	indexed, err := b.lp.LatestBlock(ctx)
+	if err != nil {
+		b.eng.Debugw("No blocks indexed by the LogPoller yet", "err", err)
+		return
+	}
+
+	// Look back from the latest block indexed by the LogPoller rather than the head, so that logs are not skipped
+	// while the LogPoller lags behind.
+	keptLogsDepth := max(b.config.FinalityDepth(), b.registrations.highestNumConfirmations)
+	from := max(min(indexed.BlockNumber, latestHead.Number)-int64(keptLogsDepth), 0)
+	if !b.initialBackfillDone && !b.config.BlockBackfillSkip() {
+		from = min(from, max(latestHead.Number-int64(b.config.BlockBackfillDepth()), 0))
+	}
+	if b.backfillFrom != nil {
+		from = min(from, *b.backfillFrom)
+	}
+
+	logs, err := b.logsToSend(ctx, b.registrations.topicsByAddress(), from, latestHead.Number)
+	if err != nil {
+		b.eng.Errorw("Failed to query LogPoller for logs", "fromBlock", from, "toBlock", latestHead.Number, "err", err)
+		return
+	}
+	if len(logs) > 0 {
+		broadcasts, err := b.orm.FindBroadcasts(ctx, from, latestHead.Number)
+		if err != nil {
+			b.eng.Errorf("Failed to query for log broadcasts, %v", err)
+			return
+		}
+		b.registrations.sendLogs(ctx, logs, latestHead, broadcasts, b.orm)
+	}
+	b.initialBackfillDone = true
+	b.backfillFrom = nil
+
+	if err := b.orm.SetPendingMinBlock(ctx, &from); err != nil {
+		b.eng.Errorw("Failed to set pending broadcasts number", "blockNumber", from, "err", err)
+	}
+
+	b.replayListeners(ctx, latestHead)
+}
+
+// replayListeners sends the logs dropped by full listener queues again, to their listener only.
+func (b *logPollerBroadcaster) replayListeners(ctx context.Context, latestHead *evmtypes.Head) {
+	for sub, from := range b.registrations.takeReplays() {
+		topics := map[common.Address][]common.Hash{sub.opts.Contract: subscriberTopics(sub)}
+		logs, err := b.logsToSend(ctx, topics, from, latestHead.Number)
+		if err != nil {
+			b.eng.Errorw("Failed to query LogPoller for logs to replay", "fromBlock", from, "jobID", sub.listener.JobID(), "err", err)
+			b.registrations.requestReplay(sub, from)
+			continue
+		}
+		if len(logs) == 0 {
+			continue
+		}
+		broadcasts, err := b.orm.FindBroadcasts(ctx, from, latestHead.Number)
+		if err != nil {
+			b.eng.Errorf("Failed to query for log broadcasts, %v", err)
+			b.registrations.requestReplay(sub, from)
+			continue
+		}
+		b.eng.Infow("Replaying dropped logs to listener", "fromBlock", from, "jobID", sub.listener.JobID())
+		b.registrations.sendLogsTo(ctx, sub, logs, latestHead, broadcasts, b.orm)
+	}
+}
+
+// logsToSend returns the logs of the given addresses and topics in [from, to], grouped by block in ascending order.
+// They are read with a single query, however many contracts are given.
+func (b *logPollerBroadcaster) logsToSend(ctx context.Context, topicsByAddress map[common.Address][]common.Hash, from, to int64) ([]logsOnBlock, error) {
+	var contracts []query.Expression
+	for addr, topics := range topicsByAddress {
+		eventSigs := make([]query.Expression, len(topics))
+		for i, topic := range topics {
+			eventSigs[i] = logpoller.NewEventSigFilter(topic)
+		}
+		contracts = append(contracts, query.And(logpoller.NewAddressFilter(addr), query.Or(eventSigs...)))
+	}
+	if len(contracts) == 0 {
+		return nil, nil
+	}
+	lpLogs, err := b.lp.FilteredLogs(ctx, []query.Expression{
+		query.Block(strconv.FormatInt(from, 10), primitives.Gte),
+		query.Block(strconv.FormatInt(to, 10), primitives.Lte),
+		query.Or(contracts...),
+	}, query.NewLimitAndSort(query.Limit{}, query.NewSortBySequence(query.Asc)), "LogBroadcaster - logsToSend")
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]types.Log, len(lpLogs))
+	for i, lpLog := range lpLogs {
+		logs[i] = lpLog.ToGethLog()
+	}
+	return groupLogsByBlock(logs), nil
+}
+
+func (b *logPollerBroadcaster) WasAlreadyConsumed(ctx context.Context, lb Broadcast) (bool, error) {
+	return b.orm.WasBroadcastConsumed(ctx, lb.RawLog().BlockHash, lb.RawLog().Index, lb.JobID())
+}
+
+// MarkConsumed marks the log as having been successfully consumed by the subscriber
+func (b *logPollerBroadcaster) MarkConsumed(ctx context.Context, ds sqlutil.DataSource, lb Broadcast) error {
+	orm := b.orm
+	if ds != nil {
+		orm = orm.WithDataSource(ds)
+	}
+	return orm.MarkBroadcastConsumed(ctx, lb.RawLog().BlockHash, lb.RawLog().BlockNumber, lb.RawLog().Index, lb.JobID())
+}
@@ -0,0 +1,248 @@
+package log
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated"
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+// fakeLogPoller serves FilteredLogs from an in-memory slice of logs, up to the latest indexed block. Only the block
+// range of the query is applied, the logs are expected to match the registered filters.
+type fakeLogPoller struct {
+	logpoller.LogPoller
+
+	mu      sync.Mutex
+	latest  int64
+	logs    []logpoller.Log
+	filters map[string]logpoller.Filter
+	queries int
+}
+
+func (f *fakeLogPoller) RegisterFilter(_ context.Context, filter logpoller.Filter) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filters[filter.Name] = filter
+	return nil
+}
+
+func (f *fakeLogPoller) UnregisterFilter(_ context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.filters, name)
+	return nil
+}
+
+func (f *fakeLogPoller) getFilters() map[string]logpoller.Filter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	filters := make(map[string]logpoller.Filter, len(f.filters))
+	for name, filter := range f.filters {
+		filters[name] = filter
+	}
+	return filters
+}
+
+func (f *fakeLogPoller) LatestBlock(context.Context) (logpoller.Block, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return logpoller.Block{BlockNumber: f.latest}, nil
+}
+
+func (f *fakeLogPoller) FilteredLogs(_ context.Context, filter []query.Expression, _ query.LimitAndSort, _ string) ([]logpoller.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries++
+	start, end := int64(0), f.latest
+	for _, expr := range filter {
+		if block, ok := expr.Primitive.(*primitives.Block); ok {
+			n, err := strconv.ParseInt(block.Block, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if block.Operator == primitives.Gte {
+				start = n
+			} else {
+				end = min(end, n)
+			}
+		}
+	}
+	var logs []logpoller.Log
+	for _, l := range f.logs {
+		if l.BlockNumber >= start && l.BlockNumber <= end {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+// fakeBroadcastsORM keeps the consumption state of broadcasts in memory.
+type fakeBroadcastsORM struct {
+	ORM
+
+	mu       sync.Mutex
+	consumed map[LogBroadcastAsKey]bool
+}
+
+func (o *fakeBroadcastsORM) key(blockHash common.Hash, logIndex uint, jobID int32) LogBroadcastAsKey {
+	return LogBroadcastAsKey{BlockHash: blockHash, LogIndex: logIndex, JobId: jobID}
+}
+
+func (o *fakeBroadcastsORM) FindBroadcasts(context.Context, int64, int64) ([]LogBroadcast, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var broadcasts []LogBroadcast
+	for k, consumed := range o.consumed {
+		broadcasts = append(broadcasts, LogBroadcast{BlockHash: k.BlockHash, LogIndex: k.LogIndex, JobID: k.JobId, Consumed: consumed})
+	}
+	return broadcasts, nil
+}
+
+func (o *fakeBroadcastsORM) CreateBroadcast(_ context.Context, blockHash common.Hash, _ uint64, logIndex uint, jobID int32) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.consumed[o.key(blockHash, logIndex, jobID)] = false
+	return nil
+}
+
+func (o *fakeBroadcastsORM) WasBroadcastConsumed(_ context.Context, blockHash common.Hash, logIndex uint, jobID int32) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.consumed[o.key(blockHash, logIndex, jobID)], nil
+}
+
+func (o *fakeBroadcastsORM) MarkBroadcastConsumed(_ context.Context, blockHash common.Hash, _ uint64, logIndex uint, jobID int32) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.consumed[o.key(blockHash, logIndex, jobID)] = true
+	return nil
+}
+
+func (o *fakeBroadcastsORM) Reinitialize(context.Context) (*int64, error) { return nil, nil }
+
+func (o *fakeBroadcastsORM) SetPendingMinBlock(context.Context, *int64) error { return nil }
+
+type testBroadcasterConfig struct{}
+
+func (testBroadcasterConfig) BlockBackfillDepth() uint64   { return 10 }
+func (testBroadcasterConfig) BlockBackfillSkip() bool      { return false }
+func (testBroadcasterConfig) FinalityDepth() uint32        { return 5 }
+func (testBroadcasterConfig) LogBackfillBatchSize() uint32 { return 100 }
+
+type testLog struct{}
+
+func (testLog) Topic() common.Hash { return common.Hash{} }
+
+type chanListener struct {
+	jobID int32
+	logs  chan Broadcast
+}
+
+func (l *chanListener) JobID() int32 { return l.jobID }
+
+func (l *chanListener) HandleLog(_ context.Context, b Broadcast) { l.logs <- b }
+
+func TestLogPollerBroadcaster(t *testing.T) {
+	ctx := testutils.Context(t)
+	contract := testutils.NewAddress()
+	eventSig := common.HexToHash("0x01")
+	lp := &fakeLogPoller{latest: 10, filters: make(map[string]logpoller.Filter)}
+	lp.logs = []logpoller.Log{
+		{BlockNumber: 8, BlockHash: common.HexToHash("0x08"), LogIndex: 0, Address: contract, EventSig: eventSig, Topics: [][]byte{eventSig.Bytes()}},
+		{BlockNumber: 10, BlockHash: common.HexToHash("0x0a"), LogIndex: 1, Address: contract, EventSig: eventSig, Topics: [][]byte{eventSig.Bytes()}},
+	}
+	orm := &fakeBroadcastsORM{consumed: make(map[LogBroadcastAsKey]bool)}
+
+	lb := NewLogPollerBroadcaster(orm, lp, testBroadcasterConfig{}, logger.Test(t), *testutils.FixtureChainID)
+	listener := &chanListener{jobID: 1, logs: make(chan Broadcast, 10)}
+	unsubscribe := lb.Register(listener, ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]Topic{eventSig: {}},
+		ParseLog:                 func(types.Log) (generated.AbigenLog, error) { return testLog{}, nil },
+		MinIncomingConfirmations: 2,
+	})
+	lb.AddDependents(1)
+	servicetest.Run(t, lb)
+	lb.DependentReady()
+
+	require.Eventually(t, func() bool { return len(lp.getFilters()) == 1 }, testutils.WaitTimeout(t), 10*time.Millisecond)
+	filter := lp.getFilters()[logpoller.FilterName("LogBroadcaster", "1", contract, "1")]
+	assert.Equal(t, evmtypes.AddressArray{contract}, filter.Addresses)
+	assert.Equal(t, evmtypes.HashArray{eventSig}, filter.EventSigs)
+
+	// Every listener gets its own filter, so unregistering one leaves the others in place.
+	otherEventSig := common.HexToHash("0x02")
+	unsubscribeOther := lb.Register(&chanListener{jobID: 2, logs: make(chan Broadcast, 10)}, ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]Topic{otherEventSig: {}},
+		ParseLog:                 func(types.Log) (generated.AbigenLog, error) { return testLog{}, nil },
+		MinIncomingConfirmations: 2,
+	})
+	require.Eventually(t, func() bool { return len(lp.getFilters()) == 2 }, testutils.WaitTimeout(t), 10*time.Millisecond)
+	unsubscribeOther()
+	require.Eventually(t, func() bool { return len(lp.getFilters()) == 1 }, testutils.WaitTimeout(t), 10*time.Millisecond)
+	assert.Contains(t, lp.getFilters(), filter.Name)
+
+	receive := func() Broadcast {
+		select {
+		case b := <-listener.logs:
+			return b
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for log")
+			return nil
+		}
+	}
+	assertNoLog := func() {
+		select {
+		case b := <-listener.logs:
+			t.Fatalf("unexpected log from block %d", b.RawLog().BlockNumber)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	// Only the log in block 8 has 2 confirmations at block 10.
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 10, Hash: common.HexToHash("0x0a")})
+	b := receive()
+	assert.Equal(t, uint64(8), b.RawLog().BlockNumber)
+	assert.Equal(t, int32(1), b.JobID())
+	assertNoLog()
+
+	consumed, err := lb.WasAlreadyConsumed(ctx, b)
+	require.NoError(t, err)
+	assert.False(t, consumed)
+	require.NoError(t, lb.MarkConsumed(ctx, nil, b))
+	consumed, err = lb.WasAlreadyConsumed(ctx, b)
+	require.NoError(t, err)
+	assert.True(t, consumed)
+
+	// The log in block 8 was consumed, so only the log in block 10 is sent once it has 2 confirmations.
+	lp.mu.Lock()
+	lp.latest = 11
+	lp.mu.Unlock()
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 11, Hash: common.HexToHash("0x0b")})
+	b = receive()
+	assert.Equal(t, uint64(10), b.RawLog().BlockNumber)
+	assertNoLog()
+	lp.mu.Lock()
+	assert.Equal(t, 2, lp.queries, "logs are read with one query per head")
+	lp.mu.Unlock()
+
+	unsubscribe()
+	require.Eventually(t, func() bool { return len(lp.getFilters()) == 0 }, testutils.WaitTimeout(t), 10*time.Millisecond)
+}
@@ -1,7 +1,9 @@
 package log
 
 import (
+	"cmp"
 	"math"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -187,6 +189,24 @@ type logsOnBlock struct {
 	Logs        []types.Log
 }
 
+// groupLogsByBlock groups logs by block, in ascending order of block number and log index.
+func groupLogsByBlock(logs []types.Log) []logsOnBlock {
+	slices.SortFunc(logs, func(a, b types.Log) int {
+		if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Index, b.Index)
+	})
+	var blocks []logsOnBlock
+	for _, l := range logs {
+		if len(blocks) == 0 || blocks[len(blocks)-1].BlockNumber != l.BlockNumber {
+			blocks = append(blocks, logsOnBlock{BlockNumber: l.BlockNumber})
+		}
+		blocks[len(blocks)-1].Logs = append(blocks[len(blocks)-1].Logs, l)
+	}
+	return blocks
+}
+
 func newLogsOnBlock(num uint64, logsMap map[uint]map[uint]types.Log) logsOnBlock {
 	logs := make([]types.Log, 0, len(logsMap))
 	for _, txLogs := range logsMap {
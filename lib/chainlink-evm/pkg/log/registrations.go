@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -52,10 +53,17 @@ type (
 		// highest 'NumConfirmations' per all listeners, used to decide about deleting older logs if it's higher than EvmFinalityDepth
 		// it's: max(listeners.map(l => l.num_confirmations)
 		highestNumConfirmations uint32
+
+		// queues holds the delivery queues of the subscribers registered with a QueueSize
+		queues map[*subscriber]*listenerQueue
+		// replays holds the block of the earliest log dropped by the queue of each subscriber with the OverflowReplay policy
+		replays map[*subscriber]int64
 	}
 
 	handler struct {
 		lookupSubs map[common.Address]map[common.Hash]subscribers // contractAddress => logTopic => *subscriber => topicValueFilters
+		queues     map[*subscriber]*listenerQueue
+		replays    map[*subscriber]int64
 		evmChainID big.Int
 		logger     logger.SugaredLogger
 	}
@@ -75,6 +83,8 @@ func newRegistrations(lggr logger.Logger, evmChainID big.Int) *registrations {
 		registeredSubs:  make(map[*subscriber]struct{}),
 		jobIDAddrs:      make(map[int32]map[common.Address]struct{}),
 		handlersByConfs: make(map[uint32]*handler),
+		queues:          make(map[*subscriber]*listenerQueue),
+		replays:         make(map[*subscriber]int64),
 		evmChainID:      evmChainID,
 		logger:          logger.Sugared(logger.Named(lggr, "Registrations")),
 	}
@@ -89,10 +99,14 @@ func (r *registrations) addSubscriber(sub *subscriber) (needsResubscribe bool) {
 
 	handler, exists := r.handlersByConfs[sub.opts.MinIncomingConfirmations]
 	if !exists {
-		handler = newHandler(r.logger, r.evmChainID)
+		handler = newHandler(r.logger, r.evmChainID, r.queues, r.replays)
 		r.handlersByConfs[sub.opts.MinIncomingConfirmations] = handler
 	}
 
+	if sub.opts.QueueSize > 0 {
+		r.queues[sub] = newListenerQueue(sub.listener, sub.opts.Contract, r.evmChainID, sub.opts.QueueSize, sub.opts.OverflowPolicy)
+	}
+
 	needsResubscribe = handler.addSubscriber(sub, r.handlersWithGreaterConfs(sub.opts.MinIncomingConfirmations))
 
 	// increase the variable for highest number of confirmations among all subscribers,
@@ -144,6 +158,13 @@ func (r *registrations) removeSubscriber(sub *subscriber) (needsResubscribe bool
 	}
 	r.logger.Tracef("Removed subscription %p with job ID %v", sub, sub.listener.JobID())
 
+	if queue, exists := r.queues[sub]; exists {
+		queue.close()
+		delete(r.queues, sub)
+	}
+	delete(r.replays, sub)
+	deleteListenerMetrics(r.evmChainID, sub.listener.JobID(), sub.opts.Contract)
+
 	handlers, exists := r.handlersByConfs[sub.opts.MinIncomingConfirmations]
 	if !exists {
 		return
@@ -183,6 +204,51 @@ func (r *registrations) checkRemoveSubscriber(sub *subscriber) error {
 	return nil
 }
 
+// requestReplay replays the logs from fromBlock to sub, once its queue is idle.
+func (r *registrations) requestReplay(sub *subscriber, fromBlock int64) {
+	addReplay(r.replays, sub, fromBlock)
+}
+
+func addReplay(replays map[*subscriber]int64, sub *subscriber, fromBlock int64) {
+	if from, exists := replays[sub]; !exists || fromBlock < from {
+		replays[sub] = fromBlock
+	}
+}
+
+// takeReplays returns the block to replay from for every subscriber which dropped logs and handled its queue since,
+// and forgets them. The broadcaster must read the logs from that block again and send them with sendLogsTo.
+func (r *registrations) takeReplays() map[*subscriber]int64 {
+	replays := make(map[*subscriber]int64)
+	for sub, from := range r.replays {
+		if queue, exists := r.queues[sub]; exists && !queue.idle() {
+			continue
+		}
+		replays[sub] = from
+		delete(r.replays, sub)
+	}
+	return replays
+}
+
+// subscriberTopics returns the event topics sub listens to.
+func subscriberTopics(sub *subscriber) []common.Hash {
+	topics := make([]common.Hash, 0, len(sub.opts.LogsWithTopics))
+	for topic := range sub.opts.LogsWithTopics {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// close stops the delivery queues of all subscribers and removes their metrics.
+func (r *registrations) close() {
+	for sub, queue := range r.queues {
+		queue.close()
+		delete(r.queues, sub)
+	}
+	for sub := range r.registeredSubs {
+		deleteListenerMetrics(r.evmChainID, sub.listener.JobID(), sub.opts.Contract)
+	}
+}
+
 // reset the number tracking highest num confirmations among all subscribers
 func (r *registrations) resetHighestNumConfirmationsValue() {
 	highestNumConfirmations := uint32(0)
@@ -206,6 +272,28 @@ func (r *registrations) addressesAndTopics() ([]common.Address, []common.Hash) {
 	return addresses, topics
 }
 
+// topicsByAddress returns the event topics registered for each contract address.
+func (r *registrations) topicsByAddress() map[common.Address][]common.Hash {
+	seen := make(map[common.Address]map[common.Hash]struct{})
+	for _, handler := range r.handlersByConfs {
+		for addr, topics := range handler.lookupSubs {
+			if _, exists := seen[addr]; !exists {
+				seen[addr] = make(map[common.Hash]struct{})
+			}
+			for topic := range topics {
+				seen[addr][topic] = struct{}{}
+			}
+		}
+	}
+	topicsByAddr := make(map[common.Address][]common.Hash, len(seen))
+	for addr, topics := range seen {
+		for topic := range topics {
+			topicsByAddr[addr] = append(topicsByAddr[addr], topic)
+		}
+	}
+	return topicsByAddr
+}
+
 func (r *registrations) isAddressRegistered(address common.Address) bool {
 	for _, sub := range r.handlersByConfs {
 		if sub.isAddressRegistered(address) {
@@ -216,6 +304,11 @@ func (r *registrations) isAddressRegistered(address common.Address) bool {
 }
 
 func (r *registrations) sendLogs(ctx context.Context, logsToSend []logsOnBlock, latestHead *evmtypes.Head, broadcasts []LogBroadcast, bc broadcastCreator) {
+	r.sendLogsTo(ctx, nil, logsToSend, latestHead, broadcasts, bc)
+}
+
+// sendLogsTo is like sendLogs, but only sends the logs to only, if it is not nil.
+func (r *registrations) sendLogsTo(ctx context.Context, only *subscriber, logsToSend []logsOnBlock, latestHead *evmtypes.Head, broadcasts []LogBroadcast, bc broadcastCreator) {
 	broadcastsExisting := make(map[LogBroadcastAsKey]bool)
 	for _, b := range broadcasts {
 		broadcastsExisting[b.AsKey()] = b.Consumed
@@ -225,6 +318,9 @@ func (r *registrations) sendLogs(ctx context.Context, logsToSend []logsOnBlock,
 
 	for _, logsPerBlock := range logsToSend {
 		for numConfirmations, handlers := range r.handlersByConfs {
+			if only != nil && only.opts.MinIncomingConfirmations != numConfirmations {
+				continue
+			}
 			if numConfirmations != 0 && latestBlockNumber < uint64(numConfirmations) {
 				// Skipping send because the block is definitely too young
 				continue
@@ -238,7 +334,7 @@ func (r *registrations) sendLogs(ctx context.Context, logsToSend []logsOnBlock,
 			}
 
 			for _, log := range logsPerBlock.Logs {
-				handlers.sendLog(ctx, log, latestHead, broadcastsExisting, bc, r.logger)
+				handlers.sendLog(ctx, log, latestHead, broadcastsExisting, bc, r.logger, only)
 				if ctx.Err() != nil {
 					return
 				}
@@ -265,9 +361,11 @@ func filtersContainValues(topicValues []common.Hash, filters [][]Topic) bool {
 	return true
 }
 
-func newHandler(lggr logger.SugaredLogger, evmChainID big.Int) *handler {
+func newHandler(lggr logger.SugaredLogger, evmChainID big.Int, queues map[*subscriber]*listenerQueue, replays map[*subscriber]int64) *handler {
 	return &handler{
 		lookupSubs: make(map[common.Address]map[common.Hash]subscribers),
+		queues:     queues,
+		replays:    replays,
 		evmChainID: evmChainID,
 		logger:     lggr,
 	}
@@ -390,12 +488,16 @@ type broadcastCreator interface {
 func (r *handler) sendLog(ctx context.Context, log types.Log, latestHead *evmtypes.Head,
 	broadcasts map[LogBroadcastAsKey]bool,
 	bc broadcastCreator,
-	logger logger.Logger) {
+	logger logger.Logger,
+	only *subscriber) {
 	topic := log.Topics[0]
 
 	latestBlockNumber := uint64(latestHead.Number)
 	var wg sync.WaitGroup
 	for sub, filters := range r.lookupSubs[log.Address][topic] {
+		if only != nil && sub != only {
+			continue
+		}
 		currentBroadcast := NewLogBroadcastAsKey(log, sub.listener)
 		consumed, exists := broadcasts[currentBroadcast]
 		if exists && consumed {
@@ -437,23 +539,37 @@ func (r *handler) sendLog(ctx context.Context, log types.Log, latestHead *evmtyp
 			"blockNumber", log.BlockNumber, "blockHash", log.BlockHash,
 			"address", log.Address, "latestBlockNumber", latestBlockNumber, "jobID", jobID)
 
+		b := &broadcast{
+			latestBlockNumber,
+			latestHead.Hash,
+			latestHead.ReceiptsRoot,
+			latestHead.TransactionsRoot,
+			latestHead.StateRoot,
+			decodedLog,
+			logCopy,
+			jobID,
+			r.evmChainID,
+		}
+
+		if queue, exists := r.queues[sub]; exists {
+			if !queue.enqueue(ctx, b) {
+				logger.Errorw("Queue of listener is full, dropping log and replaying it to the listener once it caught up", "blockNumber", log.BlockNumber,
+					"blockHash", log.BlockHash, "address", log.Address, "jobID", jobID, "queueSize", sub.opts.QueueSize)
+				addReplay(r.replays, sub, int64(log.BlockNumber))
+			}
+			continue
+		}
+
 		// must copy function pointer here since range pointer (sub) may not be
 		// used in goroutine below
 		handleLog := sub.listener.HandleLog
+		contract := sub.opts.Contract
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			handleLog(ctx, &broadcast{
-				latestBlockNumber,
-				latestHead.Hash,
-				latestHead.ReceiptsRoot,
-				latestHead.TransactionsRoot,
-				latestHead.StateRoot,
-				decodedLog,
-				logCopy,
-				jobID,
-				r.evmChainID,
-			})
+			start := time.Now()
+			handleLog(ctx, b)
+			observeHandleLog(r.evmChainID, jobID, contract, start)
 		}()
 	}
 	wg.Wait()
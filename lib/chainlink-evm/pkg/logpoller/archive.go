@@ -0,0 +1,768 @@
+package logpoller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// ArchiveFormat is the encoding of a LogPoller archive created by ExportArchive.
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatJSONL writes one JSON object per line.
+	ArchiveFormatJSONL ArchiveFormat = "jsonl"
+	// ArchiveFormatParquet writes a single Parquet file with one row per block or log, distinguished by the type column.
+	ArchiveFormatParquet ArchiveFormat = "parquet"
+)
+
+const (
+	archiveRecordBlock = "block"
+	archiveRecordLog   = "log"
+
+	// parquetArchiveRowGroupSize is the number of rows buffered before a Parquet row group is flushed, and read at once.
+	parquetArchiveRowGroupSize = 10000
+	// archivePageSize is the number of blocks exported at once.
+	archivePageSize = 1000
+	// archiveImportBlockBatchSize is the number of blocks validated against the canonical chain with a single batch call.
+	archiveImportBlockBatchSize = 100
+	// archiveImportLogBatchSize is the number of logs inserted at once.
+	archiveImportLogBatchSize = 1000
+)
+
+var (
+	ErrArchiveBlockMismatch = errors.New("archive block hash does not match stored block")
+	ErrArchiveChainMismatch = errors.New("archive was exported from a different chain")
+	ErrArchiveOrphanLog     = errors.New("archive log does not belong to an archived block")
+)
+
+// ArchiveOpts selects the data written by ExportArchive.
+type ArchiveOpts struct {
+	Format ArchiveFormat
+	// Start and End define the inclusive block range to export.
+	Start, End int64
+	// FilterName optionally restricts exported logs to the ones matching a single registered filter.
+	// Blocks in range are always exported, since they are needed to validate continuity on import.
+	FilterName string
+}
+
+// ArchiveStats summarizes the records processed by ExportArchive or ImportArchive.
+type ArchiveStats struct {
+	Blocks int
+	Logs   int
+	// SkippedLogs counts imported logs that were dropped, because they don't match any registered filter
+	// or all of their matching filters would have already pruned them.
+	SkippedLogs int
+}
+
+// archiveRecord is a single line of a JSONL archive or row of a Parquet archive.
+// Block records leave the log fields empty and vice versa.
+type archiveRecord struct {
+	Type                 string          `json:"type"`
+	EVMChainID           *ubig.Big       `json:"evmChainId"`
+	BlockHash            common.Hash     `json:"blockHash"`
+	BlockNumber          int64           `json:"blockNumber"`
+	BlockTimestamp       time.Time       `json:"blockTimestamp"`
+	FinalizedBlockNumber int64           `json:"finalizedBlockNumber,omitempty"`
+	SafeBlockNumber      int64           `json:"safeBlockNumber,omitempty"`
+	LogIndex             int64           `json:"logIndex,omitempty"`
+	Address              common.Address  `json:"address,omitempty"`
+	EventSig             common.Hash     `json:"eventSig,omitempty"`
+	Topics               []common.Hash   `json:"topics,omitempty"`
+	TxHash               common.Hash     `json:"txHash,omitempty"`
+	Data                 hexutil.Bytes   `json:"data,omitempty"`
+	TxFrom               *common.Address `json:"txFrom,omitempty"`
+	TxTo                 *common.Address `json:"txTo,omitempty"`
+	TxStatus             *uint64         `json:"txStatus,omitempty"`
+	TxGasUsed            *uint64         `json:"txGasUsed,omitempty"`
+}
+
+func blockRecord(b Block) archiveRecord {
+	return archiveRecord{
+		Type:                 archiveRecordBlock,
+		EVMChainID:           b.EVMChainID,
+		BlockHash:            b.BlockHash,
+		BlockNumber:          b.BlockNumber,
+		BlockTimestamp:       b.BlockTimestamp.UTC(),
+		FinalizedBlockNumber: b.FinalizedBlockNumber,
+		SafeBlockNumber:      b.SafeBlockNumber,
+	}
+}
+
+func logRecord(l Log) archiveRecord {
+	return archiveRecord{
+		Type:           archiveRecordLog,
+		EVMChainID:     l.EVMChainID,
+		BlockHash:      l.BlockHash,
+		BlockNumber:    l.BlockNumber,
+		BlockTimestamp: l.BlockTimestamp.UTC(),
+		LogIndex:       l.LogIndex,
+		Address:        l.Address,
+		EventSig:       l.EventSig,
+		Topics:         l.GetTopics(),
+		TxHash:         l.TxHash,
+		Data:           l.Data,
+		TxFrom:         l.TxFrom,
+		TxTo:           l.TxTo,
+		TxStatus:       l.TxStatus,
+		TxGasUsed:      l.TxGasUsed,
+	}
+}
+
+func (r archiveRecord) block() Block {
+	return Block{
+		EVMChainID:           r.EVMChainID,
+		BlockHash:            r.BlockHash,
+		BlockNumber:          r.BlockNumber,
+		BlockTimestamp:       r.BlockTimestamp,
+		FinalizedBlockNumber: r.FinalizedBlockNumber,
+		SafeBlockNumber:      r.SafeBlockNumber,
+	}
+}
+
+func (r archiveRecord) log() Log {
+	return Log{
+		EVMChainID:     r.EVMChainID,
+		LogIndex:       r.LogIndex,
+		BlockHash:      r.BlockHash,
+		BlockNumber:    r.BlockNumber,
+		BlockTimestamp: r.BlockTimestamp,
+		Topics:         convertTopics(r.Topics),
+		EventSig:       r.EventSig,
+		Address:        r.Address,
+		TxHash:         r.TxHash,
+		Data:           r.Data,
+		TxFrom:         r.TxFrom,
+		TxTo:           r.TxTo,
+		TxStatus:       r.TxStatus,
+		TxGasUsed:      r.TxGasUsed,
+	}
+}
+
+// ExportArchive writes blocks and logs stored by orm in the range [opts.Start, opts.End] to w.
+// The range is exported in pages of archivePageSize blocks, each page's blocks followed by its logs, so that neither the
+// exporting nor the importing node has to hold the whole range in memory.
+// The archive can be loaded into another node's database with ImportArchive, skipping the RPC backfill of that range.
+func ExportArchive(ctx context.Context, orm ORM, w io.Writer, opts ArchiveOpts) (ArchiveStats, error) {
+	var stats ArchiveStats
+	if opts.Start > opts.End {
+		return stats, fmt.Errorf("invalid archive range [%d, %d]", opts.Start, opts.End)
+	}
+	aw, err := newArchiveWriter(w, opts.Format)
+	if err != nil {
+		return stats, err
+	}
+
+	var matcher filterMatcher
+	if opts.FilterName != "" {
+		filters, err2 := orm.LoadFilters(ctx)
+		if err2 != nil {
+			return stats, fmt.Errorf("failed to load filters: %w", err2)
+		}
+		f, ok := filters[opts.FilterName]
+		if !ok {
+			return stats, fmt.Errorf("filter %q not found", opts.FilterName)
+		}
+		matcher = newFilterMatcher(map[string]Filter{f.Name: f})
+	}
+
+	for start := opts.Start; start <= opts.End; start += archivePageSize {
+		end := min(start+archivePageSize-1, opts.End)
+		blocks, err2 := orm.GetBlocksRange(ctx, start, end)
+		if err2 != nil {
+			return stats, fmt.Errorf("failed to select blocks: %w", err2)
+		}
+		for _, b := range blocks {
+			if err = aw.write(blockRecord(b)); err != nil {
+				return stats, err
+			}
+			stats.Blocks++
+		}
+
+		logs, err2 := orm.SelectLogsByBlockRange(ctx, start, end)
+		if err2 != nil {
+			return stats, fmt.Errorf("failed to select logs: %w", err2)
+		}
+		for _, l := range logs {
+			if matcher != nil && len(matcher.match(l)) == 0 {
+				continue
+			}
+			if err = aw.write(logRecord(l)); err != nil {
+				return stats, err
+			}
+			stats.Logs++
+		}
+	}
+	return stats, aw.close()
+}
+
+// ImportArchive loads an archive created by ExportArchive into orm, within a single transaction, so that a rejected
+// archive leaves the database untouched. Records are streamed in batches, and every block is validated before it's written:
+//   - its hash must match the canonical block at the same height, fetched from ec, and the block stored at the same height, if any,
+//   - the parent hash of the canonical block must match the archived or stored block at the previous height, if any.
+//
+// Mismatches are rejected with ErrArchiveBlockMismatch. Every log must belong to a block earlier in the archive, with the
+// same hash, so orphaned logs are rejected with ErrArchiveOrphanLog.
+// Logs which don't match any registered filter, or which are older than the retention of all matching filters, are skipped,
+// since pruning would remove them right away.
+// orm must be a *DSORM or an *ObservedORM wrapping one, since the import runs in a transaction of the underlying DSORM.
+func ImportArchive(ctx context.Context, orm ORM, ec Client, r io.Reader, format ArchiveFormat) (stats ArchiveStats, err error) {
+	ar, err := newArchiveReader(ctx, r, format)
+	if err != nil {
+		return stats, err
+	}
+	defer ar.close()
+
+	filters, err := orm.LoadFilters(ctx)
+	if err != nil {
+		return stats, fmt.Errorf("failed to load filters: %w", err)
+	}
+	err = transact(ctx, orm, func(tx ORM) error {
+		imp := &archiveImporter{
+			orm:     tx,
+			ec:      ec,
+			matcher: newFilterMatcher(filters),
+			now:     time.Now(),
+			blocks:  make(map[int64]common.Hash),
+		}
+		for {
+			rec, err2 := ar.next()
+			if errors.Is(err2, io.EOF) {
+				break
+			} else if err2 != nil {
+				return err2
+			}
+			if err2 = imp.add(ctx, rec); err2 != nil {
+				return err2
+			}
+		}
+		if err2 := imp.flushBlocks(ctx); err2 != nil {
+			return err2
+		}
+		if err2 := imp.flushLogs(ctx); err2 != nil {
+			return err2
+		}
+		stats = imp.stats
+		return nil
+	})
+	if err != nil {
+		return ArchiveStats{}, err
+	}
+	return stats, nil
+}
+
+// transact runs fn in a transaction of the DSORM backing orm. An ObservedORM is unwrapped, and the transactional ORM
+// passed to fn is observed the same way.
+func transact(ctx context.Context, orm ORM, fn func(tx ORM) error) error {
+	switch o := orm.(type) {
+	case *DSORM:
+		return o.Transact(ctx, func(tx *DSORM) error {
+			return fn(tx)
+		})
+	case *ObservedORM:
+		return transact(ctx, o.ORM, func(tx ORM) error {
+			observed := *o
+			observed.ORM = tx
+			return fn(&observed)
+		})
+	default:
+		return fmt.Errorf("transactions are not supported by %T", orm)
+	}
+}
+
+// archiveImporter validates and writes the records of an archive, in batches.
+type archiveImporter struct {
+	orm     ORM
+	ec      Client
+	matcher filterMatcher
+	now     time.Time
+
+	// blocks holds the hash of every archived block by number, to validate parent links and the blocks of logs.
+	blocks        map[int64]common.Hash
+	lastBlock     int64
+	pendingBlocks []Block
+	pendingLogs   []Log
+	stats         ArchiveStats
+}
+
+func (imp *archiveImporter) add(ctx context.Context, rec archiveRecord) error {
+	chainID := imp.ec.ConfiguredChainID()
+	if rec.EVMChainID == nil || rec.EVMChainID.ToInt().Cmp(chainID) != 0 {
+		return fmt.Errorf("%w: got %v want %v", ErrArchiveChainMismatch, rec.EVMChainID, chainID)
+	}
+	switch rec.Type {
+	case archiveRecordBlock:
+		b := rec.block()
+		if len(imp.blocks) > 0 && b.BlockNumber <= imp.lastBlock {
+			return fmt.Errorf("archive block %d is out of order, previous block was %d", b.BlockNumber, imp.lastBlock)
+		}
+		imp.blocks[b.BlockNumber] = b.BlockHash
+		imp.lastBlock = b.BlockNumber
+		imp.pendingBlocks = append(imp.pendingBlocks, b)
+		if len(imp.pendingBlocks) >= archiveImportBlockBatchSize {
+			return imp.flushBlocks(ctx)
+		}
+	case archiveRecordLog:
+		l := rec.log()
+		hash, ok := imp.blocks[l.BlockNumber]
+		if !ok {
+			return fmt.Errorf("%w: log %d in block %d", ErrArchiveOrphanLog, l.LogIndex, l.BlockNumber)
+		}
+		if hash != l.BlockHash {
+			return fmt.Errorf("%w: log %d in block %d has hash %s, archived block has %s", ErrArchiveBlockMismatch, l.LogIndex, l.BlockNumber, l.BlockHash, hash)
+		}
+		if imp.matcher.expired(l, imp.now) {
+			imp.stats.SkippedLogs++
+			return nil
+		}
+		imp.pendingLogs = append(imp.pendingLogs, l)
+		if len(imp.pendingLogs) >= archiveImportLogBatchSize {
+			return imp.flushLogs(ctx)
+		}
+	default:
+		return fmt.Errorf("unknown archive record type %q", rec.Type)
+	}
+	return nil
+}
+
+// flushBlocks validates the pending blocks against the canonical chain and the stored blocks, and writes them.
+func (imp *archiveImporter) flushBlocks(ctx context.Context) error {
+	if len(imp.pendingBlocks) == 0 {
+		return nil
+	}
+	first, last := imp.pendingBlocks[0].BlockNumber, imp.pendingBlocks[len(imp.pendingBlocks)-1].BlockNumber
+	stored, err := imp.orm.GetBlocksRange(ctx, first-1, last)
+	if err != nil {
+		return fmt.Errorf("failed to select stored blocks: %w", err)
+	}
+	storedByNumber := make(map[int64]common.Hash, len(stored))
+	for _, s := range stored {
+		storedByNumber[s.BlockNumber] = s.BlockHash
+	}
+
+	reqs := make([]rpc.BatchElem, len(imp.pendingBlocks))
+	for i, b := range imp.pendingBlocks {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []any{hexutil.EncodeBig(big.NewInt(b.BlockNumber)), false},
+			Result: new(evmtypes.Head),
+		}
+	}
+	if err = imp.ec.BatchCallContext(ctx, reqs); err != nil {
+		return fmt.Errorf("failed to fetch canonical blocks: %w", err)
+	}
+
+	for i, b := range imp.pendingBlocks {
+		if reqs[i].Error != nil {
+			return fmt.Errorf("failed to fetch canonical block %d: %w", b.BlockNumber, reqs[i].Error)
+		}
+		canonical := reqs[i].Result.(*evmtypes.Head)
+		if canonical.Hash != b.BlockHash {
+			return fmt.Errorf("%w: block %d archived as %s, canonical block is %s", ErrArchiveBlockMismatch, b.BlockNumber, b.BlockHash, canonical.Hash)
+		}
+		if s, ok := storedByNumber[b.BlockNumber]; ok && s != b.BlockHash {
+			return fmt.Errorf("%w: block %d archived as %s, stored as %s", ErrArchiveBlockMismatch, b.BlockNumber, b.BlockHash, s)
+		}
+		parent, ok := imp.blocks[b.BlockNumber-1]
+		if !ok {
+			parent, ok = storedByNumber[b.BlockNumber-1]
+		}
+		if ok && canonical.ParentHash != parent {
+			return fmt.Errorf("%w: parent of block %d is %s, previous block is %s", ErrArchiveBlockMismatch, b.BlockNumber, canonical.ParentHash, parent)
+		}
+	}
+
+	for _, b := range imp.pendingBlocks {
+		if err = imp.orm.InsertBlock(ctx, b.BlockHash, b.BlockNumber, b.BlockTimestamp, b.FinalizedBlockNumber, b.SafeBlockNumber); err != nil {
+			return fmt.Errorf("failed to insert block %d: %w", b.BlockNumber, err)
+		}
+		imp.stats.Blocks++
+	}
+	imp.pendingBlocks = imp.pendingBlocks[:0]
+	return nil
+}
+
+func (imp *archiveImporter) flushLogs(ctx context.Context) error {
+	if len(imp.pendingLogs) == 0 {
+		return nil
+	}
+	if err := imp.orm.InsertLogs(ctx, imp.pendingLogs); err != nil {
+		return fmt.Errorf("failed to insert logs: %w", err)
+	}
+	imp.stats.Logs += len(imp.pendingLogs)
+	imp.pendingLogs = imp.pendingLogs[:0]
+	return nil
+}
+
+// filterMatcher finds the filters matching a log by its address, event sig and topics, the same way log pruning does.
+type filterMatcher map[common.Address]map[common.Hash][]filterTuple
+
+type filterTuple struct {
+	filter Filter
+	tuple  EventTuple
+}
+
+func newFilterMatcher(filters map[string]Filter) filterMatcher {
+	m := make(filterMatcher)
+	for _, filter := range filters {
+		for _, tuple := range filter.EventTuples() {
+			if _, ok := m[tuple.Address]; !ok {
+				m[tuple.Address] = make(map[common.Hash][]filterTuple)
+			}
+			m[tuple.Address][tuple.EventSig] = append(m[tuple.Address][tuple.EventSig], filterTuple{filter, tuple})
+		}
+	}
+	return m
+}
+
+func (m filterMatcher) match(l Log) []Filter {
+	var matching []Filter
+	for _, ft := range m[l.Address][l.EventSig] {
+		if !ft.tuple.matchesTopics(l.Topics) {
+			continue
+		}
+		if !slices.ContainsFunc(matching, func(f Filter) bool { return f.Name == ft.filter.Name }) {
+			matching = append(matching, ft.filter)
+		}
+	}
+	return matching
+}
+
+// expired mirrors DeleteExpiredLogs and SelectUnmatchedLogIDs: a log is kept only if it matches at least one filter
+// and is within the longest retention of the filters watching its address and event sig, unless one of them retains
+// logs forever.
+func (m filterMatcher) expired(l Log, now time.Time) bool {
+	if len(m.match(l)) == 0 {
+		return true
+	}
+	var maxRetention time.Duration
+	for _, ft := range m[l.Address][l.EventSig] {
+		if ft.filter.Retention == 0 {
+			return false
+		}
+		maxRetention = max(maxRetention, ft.filter.Retention)
+	}
+	return !l.BlockTimestamp.After(now.Add(-maxRetention))
+}
+
+type archiveWriter interface {
+	write(rec archiveRecord) error
+	close() error
+}
+
+func newArchiveWriter(w io.Writer, format ArchiveFormat) (archiveWriter, error) {
+	switch format {
+	case ArchiveFormatJSONL:
+		bw := bufio.NewWriter(w)
+		return &jsonlArchiveWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+	case ArchiveFormatParquet:
+		return newParquetArchiveWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+// archiveReader reads the records of an archive one by one.
+type archiveReader interface {
+	// next returns the next record, or io.EOF after the last one.
+	next() (archiveRecord, error)
+	close()
+}
+
+// newArchiveReader returns a reader of archives in the given format. Parquet archives keep their metadata at the end of
+// the file, so r must implement parquet.ReaderAtSeeker, e.g. *os.File or *bytes.Reader.
+func newArchiveReader(ctx context.Context, r io.Reader, format ArchiveFormat) (archiveReader, error) {
+	switch format {
+	case ArchiveFormatJSONL:
+		return &jsonlArchiveReader{dec: json.NewDecoder(r)}, nil
+	case ArchiveFormatParquet:
+		ras, ok := r.(parquet.ReaderAtSeeker)
+		if !ok {
+			return nil, errors.New("parquet archives must be read from a seekable reader, such as a file")
+		}
+		return newParquetArchiveReader(ctx, ras)
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+type jsonlArchiveWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (j *jsonlArchiveWriter) write(rec archiveRecord) error {
+	return j.enc.Encode(rec)
+}
+
+func (j *jsonlArchiveWriter) close() error {
+	return j.w.Flush()
+}
+
+type jsonlArchiveReader struct {
+	dec *json.Decoder
+	n   int
+}
+
+func (j *jsonlArchiveReader) next() (archiveRecord, error) {
+	var rec archiveRecord
+	if err := j.dec.Decode(&rec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return rec, err
+		}
+		return rec, fmt.Errorf("failed to decode archive record %d: %w", j.n+1, err)
+	}
+	j.n++
+	return rec, nil
+}
+
+func (j *jsonlArchiveReader) close() {}
+
+var parquetArchiveSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "type", Type: arrow.BinaryTypes.String},
+	{Name: "evm_chain_id", Type: arrow.BinaryTypes.String},
+	{Name: "block_hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: common.HashLength}},
+	{Name: "block_number", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "block_timestamp", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}},
+	{Name: "finalized_block_number", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "safe_block_number", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "log_index", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "address", Type: &arrow.FixedSizeBinaryType{ByteWidth: common.AddressLength}, Nullable: true},
+	{Name: "event_sig", Type: &arrow.FixedSizeBinaryType{ByteWidth: common.HashLength}, Nullable: true},
+	{Name: "topics", Type: arrow.ListOf(&arrow.FixedSizeBinaryType{ByteWidth: common.HashLength}), Nullable: true},
+	{Name: "tx_hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: common.HashLength}, Nullable: true},
+	{Name: "data", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "tx_from", Type: &arrow.FixedSizeBinaryType{ByteWidth: common.AddressLength}, Nullable: true},
+	{Name: "tx_to", Type: &arrow.FixedSizeBinaryType{ByteWidth: common.AddressLength}, Nullable: true},
+	{Name: "tx_status", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	{Name: "tx_gas_used", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+}, nil)
+
+// checkParquetArchiveSchema compares column names and types only, since Parquet adds field metadata on read.
+func checkParquetArchiveSchema(schema *arrow.Schema) error {
+	if schema.NumFields() != parquetArchiveSchema.NumFields() {
+		return fmt.Errorf("unexpected parquet archive schema: got %d columns, want %d", schema.NumFields(), parquetArchiveSchema.NumFields())
+	}
+	for i, want := range parquetArchiveSchema.Fields() {
+		got := schema.Field(i)
+		if got.Name != want.Name || got.Type.ID() != want.Type.ID() {
+			return fmt.Errorf("unexpected parquet archive column %d: got %s %s, want %s %s", i, got.Name, got.Type, want.Name, want.Type)
+		}
+	}
+	return nil
+}
+
+type parquetArchiveWriter struct {
+	fw *pqarrow.FileWriter
+	b  *array.RecordBuilder
+}
+
+func newParquetArchiveWriter(w io.Writer) (*parquetArchiveWriter, error) {
+	fw, err := pqarrow.NewFileWriter(parquetArchiveSchema, w, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	return &parquetArchiveWriter{fw: fw, b: array.NewRecordBuilder(memory.DefaultAllocator, parquetArchiveSchema)}, nil
+}
+
+func (p *parquetArchiveWriter) write(rec archiveRecord) error {
+	p.b.Field(0).(*array.StringBuilder).Append(rec.Type)
+	p.b.Field(1).(*array.StringBuilder).Append(rec.EVMChainID.String())
+	p.b.Field(2).(*array.FixedSizeBinaryBuilder).Append(rec.BlockHash.Bytes())
+	p.b.Field(3).(*array.Int64Builder).Append(rec.BlockNumber)
+	p.b.Field(4).(*array.TimestampBuilder).Append(arrow.Timestamp(rec.BlockTimestamp.UnixMicro()))
+
+	finalized, safe := p.b.Field(5).(*array.Int64Builder), p.b.Field(6).(*array.Int64Builder)
+	logIndex := p.b.Field(7).(*array.Int64Builder)
+	address, eventSig := p.b.Field(8).(*array.FixedSizeBinaryBuilder), p.b.Field(9).(*array.FixedSizeBinaryBuilder)
+	topics := p.b.Field(10).(*array.ListBuilder)
+	txHash, data := p.b.Field(11).(*array.FixedSizeBinaryBuilder), p.b.Field(12).(*array.BinaryBuilder)
+	txFrom, txTo := p.b.Field(13).(*array.FixedSizeBinaryBuilder), p.b.Field(14).(*array.FixedSizeBinaryBuilder)
+	txStatus, txGasUsed := p.b.Field(15).(*array.Uint64Builder), p.b.Field(16).(*array.Uint64Builder)
+	appendAddress := func(b *array.FixedSizeBinaryBuilder, addr *common.Address) {
+		if addr == nil {
+			b.AppendNull()
+			return
+		}
+		b.Append(addr.Bytes())
+	}
+	appendUint64 := func(b *array.Uint64Builder, v *uint64) {
+		if v == nil {
+			b.AppendNull()
+			return
+		}
+		b.Append(*v)
+	}
+	appendAddress(txFrom, rec.TxFrom)
+	appendAddress(txTo, rec.TxTo)
+	appendUint64(txStatus, rec.TxStatus)
+	appendUint64(txGasUsed, rec.TxGasUsed)
+	if rec.Type == archiveRecordBlock {
+		finalized.Append(rec.FinalizedBlockNumber)
+		safe.Append(rec.SafeBlockNumber)
+		for _, b := range []array.Builder{logIndex, address, eventSig, topics, txHash, data} {
+			b.AppendNull()
+		}
+	} else {
+		finalized.AppendNull()
+		safe.AppendNull()
+		logIndex.Append(rec.LogIndex)
+		address.Append(rec.Address.Bytes())
+		eventSig.Append(rec.EventSig.Bytes())
+		topics.Append(true)
+		topicValues := topics.ValueBuilder().(*array.FixedSizeBinaryBuilder)
+		for _, t := range rec.Topics {
+			topicValues.Append(t.Bytes())
+		}
+		txHash.Append(rec.TxHash.Bytes())
+		data.Append(rec.Data)
+	}
+
+	if p.b.Field(0).Len() >= parquetArchiveRowGroupSize {
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *parquetArchiveWriter) flush() error {
+	rec := p.b.NewRecord()
+	defer rec.Release()
+	if rec.NumRows() == 0 {
+		return nil
+	}
+	return p.fw.Write(rec)
+}
+
+func (p *parquetArchiveWriter) close() error {
+	defer p.b.Release()
+	if err := p.flush(); err != nil {
+		return err
+	}
+	return p.fw.Close()
+}
+
+// parquetArchiveReader streams the rows of a Parquet archive, one batch of parquetArchiveRowGroupSize rows at a time.
+type parquetArchiveReader struct {
+	rr  pqarrow.RecordReader
+	rec arrow.Record
+	row int
+	n   int
+}
+
+func newParquetArchiveReader(ctx context.Context, r parquet.ReaderAtSeeker) (*parquetArchiveReader, error) {
+	pf, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet archive: %w", err)
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: parquetArchiveRowGroupSize}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet archive: %w", err)
+	}
+	schema, err := fr.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet archive schema: %w", err)
+	}
+	if err = checkParquetArchiveSchema(schema); err != nil {
+		return nil, err
+	}
+	rr, err := fr.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet archive: %w", err)
+	}
+	return &parquetArchiveReader{rr: rr}, nil
+}
+
+func (p *parquetArchiveReader) next() (archiveRecord, error) {
+	for p.rec == nil || p.row >= int(p.rec.NumRows()) {
+		if !p.rr.Next() {
+			if err := p.rr.Err(); err != nil && !errors.Is(err, io.EOF) {
+				return archiveRecord{}, fmt.Errorf("failed to read parquet archive: %w", err)
+			}
+			return archiveRecord{}, io.EOF
+		}
+		p.rec, p.row = p.rr.Record(), 0
+	}
+	ar, err := parquetArchiveRow(p.rec, p.row)
+	if err != nil {
+		return ar, fmt.Errorf("invalid archive row %d: %w", p.n+1, err)
+	}
+	p.row++
+	p.n++
+	return ar, nil
+}
+
+func (p *parquetArchiveReader) close() {
+	p.rr.Release()
+}
+
+// parquetArchiveRow converts row i of rec, which must have the parquetArchiveSchema.
+func parquetArchiveRow(rec arrow.Record, i int) (archiveRecord, error) {
+	chainID := rec.Column(1).(*array.String)
+	id, ok := new(big.Int).SetString(chainID.Value(i), 10)
+	if !ok {
+		return archiveRecord{}, fmt.Errorf("invalid chain id %q", chainID.Value(i))
+	}
+	ar := archiveRecord{
+		Type:           rec.Column(0).(*array.String).Value(i),
+		EVMChainID:     ubig.New(id),
+		BlockHash:      common.BytesToHash(rec.Column(2).(*array.FixedSizeBinary).Value(i)),
+		BlockNumber:    rec.Column(3).(*array.Int64).Value(i),
+		BlockTimestamp: rec.Column(4).(*array.Timestamp).Value(i).ToTime(arrow.Microsecond),
+	}
+	if ar.Type == archiveRecordBlock {
+		ar.FinalizedBlockNumber = rec.Column(5).(*array.Int64).Value(i)
+		ar.SafeBlockNumber = rec.Column(6).(*array.Int64).Value(i)
+		return ar, nil
+	}
+
+	ar.LogIndex = rec.Column(7).(*array.Int64).Value(i)
+	ar.Address = common.BytesToAddress(rec.Column(8).(*array.FixedSizeBinary).Value(i))
+	ar.EventSig = common.BytesToHash(rec.Column(9).(*array.FixedSizeBinary).Value(i))
+	topics := rec.Column(10).(*array.List)
+	topicValues := topics.ListValues().(*array.FixedSizeBinary)
+	start, end := topics.ValueOffsets(i)
+	for j := start; j < end; j++ {
+		ar.Topics = append(ar.Topics, common.BytesToHash(topicValues.Value(int(j))))
+	}
+	ar.TxHash = common.BytesToHash(rec.Column(11).(*array.FixedSizeBinary).Value(i))
+	ar.Data = bytes.Clone(rec.Column(12).(*array.Binary).Value(i))
+	txFrom, txTo := rec.Column(13).(*array.FixedSizeBinary), rec.Column(14).(*array.FixedSizeBinary)
+	txStatus, txGasUsed := rec.Column(15).(*array.Uint64), rec.Column(16).(*array.Uint64)
+	if txFrom.IsValid(i) {
+		from := common.BytesToAddress(txFrom.Value(i))
+		ar.TxFrom = &from
+	}
+	if txTo.IsValid(i) {
+		to := common.BytesToAddress(txTo.Value(i))
+		ar.TxTo = &to
+	}
+	if txStatus.IsValid(i) {
+		status := txStatus.Value(i)
+		ar.TxStatus = &status
+	}
+	if txGasUsed.IsValid(i) {
+		gasUsed := txGasUsed.Value(i)
+		ar.TxGasUsed = &gasUsed
+	}
+	return ar, nil
+}
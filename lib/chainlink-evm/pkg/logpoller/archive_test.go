@@ -0,0 +1,105 @@
+package logpoller
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+func Test_ArchiveRoundTrip(t *testing.T) {
+	ts := time.Unix(1700000000, 0).UTC()
+	sender, status, gasUsed := common.HexToAddress("0x5"), uint64(1), uint64(21000)
+	records := []archiveRecord{
+		blockRecord(Block{
+			EVMChainID:           ubig.NewI(137),
+			BlockHash:            common.HexToHash("0x1"),
+			BlockNumber:          10,
+			BlockTimestamp:       ts,
+			FinalizedBlockNumber: 8,
+			SafeBlockNumber:      9,
+		}),
+		logRecord(Log{
+			EVMChainID:     ubig.NewI(137),
+			LogIndex:       3,
+			BlockHash:      common.HexToHash("0x1"),
+			BlockNumber:    10,
+			BlockTimestamp: ts,
+			Topics:         convertTopics([]common.Hash{common.HexToHash("0xa"), common.HexToHash("0xb")}),
+			EventSig:       common.HexToHash("0xa"),
+			Address:        common.HexToAddress("0x1234"),
+			TxHash:         common.HexToHash("0x2"),
+			Data:           []byte("hello"),
+			TxFrom:         &sender,
+			TxStatus:       &status,
+			TxGasUsed:      &gasUsed,
+		}),
+	}
+
+	for _, format := range []ArchiveFormat{ArchiveFormatJSONL, ArchiveFormatParquet} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := newArchiveWriter(&buf, format)
+			require.NoError(t, err)
+			for _, rec := range records {
+				require.NoError(t, w.write(rec))
+			}
+			require.NoError(t, w.close())
+
+			r, err := newArchiveReader(testutils.Context(t), bytes.NewReader(buf.Bytes()), format)
+			require.NoError(t, err)
+			defer r.close()
+			var got []archiveRecord
+			for {
+				rec, err := r.next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				got = append(got, rec)
+			}
+			require.Len(t, got, len(records))
+			assert.Equal(t, records[0].block(), got[0].block())
+			assert.Equal(t, records[1].log(), got[1].log())
+		})
+	}
+
+	_, err := newArchiveWriter(&bytes.Buffer{}, "csv")
+	require.Error(t, err)
+	_, err = newArchiveReader(testutils.Context(t), &bytes.Buffer{}, ArchiveFormatParquet)
+	require.ErrorContains(t, err, "seekable")
+}
+
+func Test_FilterMatcherExpired(t *testing.T) {
+	addr, sig := common.HexToAddress("0x1234"), common.HexToHash("0xa")
+	now := time.Now()
+	matcher := newFilterMatcher(map[string]Filter{
+		"short": {Name: "short", Addresses: []common.Address{addr}, EventSigs: []common.Hash{sig}, Retention: time.Hour},
+		"long":  {Name: "long", Addresses: []common.Address{addr}, EventSigs: []common.Hash{sig}, Retention: 24 * time.Hour},
+	})
+
+	assert.False(t, matcher.expired(Log{Address: addr, EventSig: sig, BlockTimestamp: now.Add(-2 * time.Hour)}, now))
+	assert.True(t, matcher.expired(Log{Address: addr, EventSig: sig, BlockTimestamp: now.Add(-48 * time.Hour)}, now))
+	assert.True(t, matcher.expired(Log{Address: addr, EventSig: common.HexToHash("0xb"), BlockTimestamp: now}, now))
+
+	matcher = newFilterMatcher(map[string]Filter{
+		"forever": {Name: "forever", Addresses: []common.Address{addr}, EventSigs: []common.Hash{sig}},
+	})
+	assert.False(t, matcher.expired(Log{Address: addr, EventSig: sig, BlockTimestamp: now.Add(-48 * time.Hour)}, now))
+
+	topicA, topicB := common.HexToHash("0xaa"), common.HexToHash("0xbb")
+	matcher = newFilterMatcher(map[string]Filter{
+		"tuple": {Name: "tuple", Tuples: []EventTuple{{Address: addr, EventSig: sig, Topic3: []common.Hash{topicA}}}},
+	})
+	assert.False(t, matcher.expired(Log{Address: addr, EventSig: sig, Topics: [][]byte{sig[:], topicB[:], topicA[:]}}, now))
+	assert.True(t, matcher.expired(Log{Address: addr, EventSig: sig, Topics: [][]byte{sig[:], topicA[:], topicB[:]}}, now))
+	assert.True(t, matcher.expired(Log{Address: addr, EventSig: sig, Topics: [][]byte{sig[:], topicA[:]}}, now))
+}
@@ -54,6 +54,14 @@ func (disabled) GetBlocksRange(ctx context.Context, numbers []uint64) ([]Block,
 	return nil, ErrDisabled
 }
 
+func (disabled) Reorgs(ctx context.Context, start, end int64) ([]Reorg, error) {
+	return nil, ErrDisabled
+}
+
+func (disabled) FilterStats(ctx context.Context) ([]FilterStats, error) {
+	return nil, ErrDisabled
+}
+
 func (disabled) Logs(ctx context.Context, start, end int64, eventSig common.Hash, address common.Address) ([]Log, error) {
 	return nil, ErrDisabled
 }
@@ -122,6 +130,14 @@ func (d disabled) FilteredLogs(_ context.Context, _ []query.Expression, _ query.
 	return nil, ErrDisabled
 }
 
+func (d disabled) IterateFilteredLogs(_ context.Context, _ []query.Expression, _ uint64, _ string) *LogIterator {
+	return &LogIterator{err: ErrDisabled}
+}
+
+func (d disabled) AggregatedLogs(_ context.Context, _ []query.Expression, _ Aggregate, _ string) ([]AggregateResult, error) {
+	return nil, ErrDisabled
+}
+
 func (d disabled) FindLCA(ctx context.Context) (*Block, error) {
 	return nil, ErrDisabled
 }
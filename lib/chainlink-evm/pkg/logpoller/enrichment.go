@@ -0,0 +1,81 @@
+package logpoller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// txEnrichment is the part of an eth_getTransactionReceipt response stored with logs of filters with EnrichTx.
+// Receipts include the sender and recipient, so the transaction itself doesn't have to be fetched.
+type txEnrichment struct {
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to"`
+	Status  hexutil.Uint64  `json:"status"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+}
+
+// enrichLogs sets the transaction fields of logs matching a filter with EnrichTx, fetching one receipt per transaction
+// in batches of rpcBatchSize. Any missing receipt fails the whole call, so that logs are never stored half enriched.
+func (lp *logPoller) enrichLogs(ctx context.Context, logs []Log) error {
+	lp.filterMu.Lock()
+	if lp.filterDirty {
+		lp.rebuildFilterCache()
+	}
+	// the cached map is replaced, never modified, when filters change, so it's safe to use after unlocking
+	enrich := lp.cachedEnrichTx
+	lp.filterMu.Unlock()
+	if len(enrich) == 0 {
+		return nil
+	}
+
+	var txHashes []common.Hash
+	byTx := make(map[common.Hash][]int)
+	for i, l := range logs {
+		if _, ok := enrich[l.Address][l.EventSig]; !ok {
+			continue
+		}
+		if _, ok := byTx[l.TxHash]; !ok {
+			txHashes = append(txHashes, l.TxHash)
+		}
+		byTx[l.TxHash] = append(byTx[l.TxHash], i)
+	}
+
+	batchSize := int(max(lp.rpcBatchSize, 1))
+	for start := 0; start < len(txHashes); start += batchSize {
+		end := min(start+batchSize, len(txHashes))
+		reqs := make([]rpc.BatchElem, 0, end-start)
+		for _, txHash := range txHashes[start:end] {
+			reqs = append(reqs, rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []any{txHash},
+				Result: new(*txEnrichment),
+			})
+		}
+		if err := lp.ec.BatchCallContext(ctx, reqs); err != nil {
+			return err
+		}
+
+		for i, req := range reqs {
+			txHash := txHashes[start+i]
+			if req.Error != nil {
+				return fmt.Errorf("failed to fetch receipt of tx %s: %w", txHash, req.Error)
+			}
+			receipt := *req.Result.(**txEnrichment)
+			if receipt == nil {
+				return fmt.Errorf("receipt of tx %s not found", txHash)
+			}
+			status, gasUsed := uint64(receipt.Status), uint64(receipt.GasUsed)
+			for _, idx := range byTx[txHash] {
+				logs[idx].TxFrom = &receipt.From
+				logs[idx].TxTo = receipt.To
+				logs[idx].TxStatus = &status
+				logs[idx].TxGasUsed = &gasUsed
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package logpoller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/mathutil"
+)
+
+// IndexerOpts configures a historical Indexer. The embedded Opts provide the finality settings and batch sizes,
+// poll and pruning settings are ignored.
+type IndexerOpts struct {
+	Opts
+	// From and To are the first and last block of the range to index, both inclusive.
+	From, To int64
+	// OnProgress, if set, is called after every indexed batch of blocks.
+	OnProgress func(IndexerProgress)
+}
+
+// IndexerProgress reports how far an Indexer got through its block range.
+type IndexerProgress struct {
+	From, To      int64
+	LastIndexed   int64 // last block of the range for which logs were saved
+	BlocksIndexed int64
+	Elapsed       time.Duration
+	ETA           time.Duration // estimated time remaining, based on the throughput so far
+}
+
+// Done returns the fraction of the range indexed so far, in [0, 1].
+func (p IndexerProgress) Done() float64 {
+	total := p.To - p.From + 1
+	if total <= 0 {
+		return 1
+	}
+	return float64(p.BlocksIndexed) / float64(total)
+}
+
+func newIndexerProgress(from, to, lastIndexed int64, elapsed time.Duration) IndexerProgress {
+	p := IndexerProgress{From: from, To: to, LastIndexed: lastIndexed, BlocksIndexed: lastIndexed - from + 1, Elapsed: elapsed}
+	if p.BlocksIndexed > 0 {
+		p.ETA = time.Duration(float64(elapsed) / float64(p.BlocksIndexed) * float64(to-lastIndexed))
+	}
+	return p
+}
+
+// Indexer saves the logs matching the registered filters for a fixed range of finalized blocks and then stops. Unlike
+// the LogPoller service it doesn't follow the chain, so it needs no head tracker. Use it to build an archive of
+// historical logs, e.g. before exporting them with ExportArchive.
+type Indexer struct {
+	lp   *logPoller
+	opts IndexerOpts
+}
+
+func NewIndexer(orm ORM, ec Client, lggr logger.Logger, opts IndexerOpts) *Indexer {
+	return &Indexer{
+		lp:   NewLogPoller(orm, ec, logger.Named(lggr, "Indexer"), nil, opts.Opts),
+		opts: opts,
+	}
+}
+
+// RegisterFilter adds a filter whose logs are saved by Run.
+func (i *Indexer) RegisterFilter(ctx context.Context, filter Filter) error {
+	return i.lp.RegisterFilter(ctx, filter)
+}
+
+// Run indexes the configured block range. The whole range must already be finalized. Logs are saved in batches of
+// BackfillBatchSize blocks, so an interrupted run can be resumed by starting at the block after the last reported
+// IndexerProgress.LastIndexed.
+func (i *Indexer) Run(ctx context.Context) error {
+	from, to := i.opts.From, i.opts.To
+	if from < 0 || to < from {
+		return fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	if i.lp.backfillBatchSize <= 0 {
+		return errors.New("BackfillBatchSize must be greater than 0")
+	}
+
+	finalized, err := i.latestFinalizedBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest finalized block: %w", err)
+	}
+	if to > finalized {
+		return fmt.Errorf("end of range %d is not finalized yet, latest finalized block is %d", to, finalized)
+	}
+
+	lggr := i.lp.lggr.With("from", from, "to", to)
+	lggr.Infow("Starting historical indexing")
+	start := time.Now()
+	for batchStart := from; batchStart <= to; batchStart += i.lp.backfillBatchSize {
+		batchEnd := mathutil.Min(batchStart+i.lp.backfillBatchSize-1, to)
+		if err = i.lp.backfill(ctx, batchStart, batchEnd); err != nil {
+			return fmt.Errorf("failed to index blocks [%d, %d]: %w", batchStart, batchEnd, err)
+		}
+
+		progress := newIndexerProgress(from, to, batchEnd, time.Since(start))
+		lggr.Debugw("Indexed batch", "lastIndexed", batchEnd, "done", progress.Done(), "eta", progress.ETA)
+		if i.opts.OnProgress != nil {
+			i.opts.OnProgress(progress)
+		}
+	}
+	lggr.Infow("Finished historical indexing", "elapsed", time.Since(start))
+	return nil
+}
+
+// latestFinalizedBlockNumber asks the RPC for the latest finalized block, using the "finalized" tag or subtracting
+// FinalityDepth from the latest block, depending on UseFinalityTag.
+func (i *Indexer) latestFinalizedBlockNumber(ctx context.Context) (int64, error) {
+	tag := finalizedBlock
+	if !i.lp.useFinalityTag {
+		tag = latestBlock
+	}
+	reqs := []rpc.BatchElem{newBlockReq(string(tag))}
+	if err := i.lp.ec.BatchCallContext(ctx, reqs); err != nil {
+		return 0, err
+	}
+	if reqs[0].Error != nil {
+		return 0, reqs[0].Error
+	}
+	head, err := validateBlockResponse(reqs[0])
+	if err != nil {
+		return 0, err
+	}
+	if tag == latestBlock {
+		return mathutil.Max(head.Number-i.lp.finalityDepth, 0), nil
+	}
+	return head.Number, nil
+}
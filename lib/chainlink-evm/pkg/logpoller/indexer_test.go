@@ -0,0 +1,62 @@
+package logpoller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client/clienttest"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+func TestIndexer_Run_RequiresFinalizedRange(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name           string
+		useFinalityTag bool
+		expectedTag    string
+		expectedErr    string
+	}{
+		{"finality tag", true, rpc.FinalizedBlockNumber.String(), "end of range 95 is not finalized yet, latest finalized block is 92"},
+		{"finality depth", false, rpc.LatestBlockNumber.String(), "end of range 95 is not finalized yet, latest finalized block is 82"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ec := clienttest.NewClient(t)
+			ec.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				reqs := args.Get(1).([]rpc.BatchElem)
+				require.Len(t, reqs, 1)
+				assert.Equal(t, tc.expectedTag, reqs[0].Args[0])
+				*reqs[0].Result.(*evmtypes.Head) = evmtypes.Head{Number: 92, Hash: common.HexToHash("0x1")}
+			})
+
+			indexer := NewIndexer(nil, ec, logger.Test(t), IndexerOpts{
+				Opts: Opts{UseFinalityTag: tc.useFinalityTag, FinalityDepth: 10, BackfillBatchSize: 10},
+				From: 50,
+				To:   95,
+			})
+			require.ErrorContains(t, indexer.Run(testutils.Context(t)), tc.expectedErr)
+		})
+	}
+
+	indexer := NewIndexer(nil, clienttest.NewClient(t), logger.Test(t), IndexerOpts{Opts: Opts{BackfillBatchSize: 10}, From: 10, To: 5})
+	require.ErrorContains(t, indexer.Run(testutils.Context(t)), "invalid block range")
+}
+
+func TestIndexerProgress(t *testing.T) {
+	p := newIndexerProgress(100, 199, 124, 10*time.Second)
+	assert.Equal(t, int64(25), p.BlocksIndexed)
+	assert.InDelta(t, 0.25, p.Done(), 1e-9)
+	assert.Equal(t, 30*time.Second, p.ETA)
+
+	p = newIndexerProgress(100, 199, 199, 40*time.Second)
+	assert.InDelta(t, 1.0, p.Done(), 1e-9)
+	assert.Equal(t, time.Duration(0), p.ETA)
+}
@@ -0,0 +1,99 @@
+package logpoller
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+)
+
+const (
+	// DefaultIteratorPageSize is used by LogIterator when no page size is given.
+	DefaultIteratorPageSize = 1000
+	// MaxIteratorPageSize bounds the number of logs a LogIterator holds in memory at once.
+	MaxIteratorPageSize = 10000
+)
+
+// LogPosition identifies a log within a chain by its block number and log index.
+type LogPosition struct {
+	BlockNumber int64
+	LogIndex    int64
+}
+
+// LogIterator streams the logs matching a query DSL filter in ascending (block_number, log_index) order, using keyset
+// pagination so that only a single page of logs is held in memory and scanning stays fast deep into the table.
+// Logs inserted behind the iterator's position while it runs are not returned, and logs removed by a reorg may
+// have been returned already.
+//
+//	it := lp.IterateFilteredLogs(ctx, filter, 0, "export")
+//	for it.Next() {
+//		process(it.Log())
+//	}
+//	if err := it.Err(); err != nil { ... }
+type LogIterator struct {
+	ctx       context.Context
+	orm       ORM
+	filter    []query.Expression
+	pageSize  uint64
+	queryName string
+
+	page    []Log
+	idx     int
+	after   *LogPosition
+	current Log
+	done    bool
+	err     error
+}
+
+// NewLogIterator creates an iterator over the logs stored by orm matching filter. A pageSize of 0 selects
+// DefaultIteratorPageSize, larger values are capped at MaxIteratorPageSize. Cancelling ctx stops the iteration.
+func NewLogIterator(ctx context.Context, orm ORM, filter []query.Expression, pageSize uint64, queryName string) *LogIterator {
+	if pageSize == 0 {
+		pageSize = DefaultIteratorPageSize
+	}
+	return &LogIterator{
+		ctx:       ctx,
+		orm:       orm,
+		filter:    filter,
+		pageSize:  min(pageSize, MaxIteratorPageSize),
+		queryName: queryName,
+	}
+}
+
+// Next advances to the next log, fetching a new page when the current one is exhausted. It returns false once all
+// logs were returned or an error occurred, which is then available from Err.
+func (it *LogIterator) Next() bool {
+	if it.err != nil || (it.done && it.idx >= len(it.page)) {
+		return false
+	}
+	if it.err = it.ctx.Err(); it.err != nil {
+		return false
+	}
+
+	if it.idx >= len(it.page) {
+		it.page, it.err = it.orm.FilteredLogsPage(it.ctx, it.filter, it.after, it.pageSize, it.queryName)
+		if it.err != nil {
+			return false
+		}
+		it.idx = 0
+		it.done = uint64(len(it.page)) < it.pageSize
+		if len(it.page) == 0 {
+			return false
+		}
+		last := it.page[len(it.page)-1]
+		it.after = &LogPosition{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex}
+	}
+
+	it.current = it.page[it.idx]
+	it.idx++
+	return true
+}
+
+// Log returns the log Next advanced to.
+func (it *LogIterator) Log() Log {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *LogIterator) Err() error {
+	return it.err
+}
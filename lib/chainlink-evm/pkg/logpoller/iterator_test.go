@@ -0,0 +1,91 @@
+package logpoller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+)
+
+// pagedORM serves FilteredLogsPage from logs sorted by block number and log index.
+type pagedORM struct {
+	ORM
+	logs      []Log
+	pageSizes []uint64
+	err       error
+}
+
+func (o *pagedORM) FilteredLogsPage(_ context.Context, _ []query.Expression, after *LogPosition, pageSize uint64, _ string) ([]Log, error) {
+	o.pageSizes = append(o.pageSizes, pageSize)
+	if o.err != nil {
+		return nil, o.err
+	}
+	var page []Log
+	for _, l := range o.logs {
+		if after != nil && (l.BlockNumber < after.BlockNumber || (l.BlockNumber == after.BlockNumber && l.LogIndex <= after.LogIndex)) {
+			continue
+		}
+		if uint64(len(page)) == pageSize {
+			break
+		}
+		page = append(page, l)
+	}
+	return page, nil
+}
+
+func TestLogIterator(t *testing.T) {
+	t.Parallel()
+
+	var logs []Log
+	for block := int64(1); block <= 3; block++ {
+		for idx := int64(0); idx < 2; idx++ {
+			logs = append(logs, Log{BlockNumber: block, LogIndex: idx})
+		}
+	}
+
+	t.Run("streams all pages", func(t *testing.T) {
+		t.Parallel()
+		orm := &pagedORM{logs: logs}
+		it := NewLogIterator(context.Background(), orm, nil, 4, "")
+
+		var got []Log
+		for it.Next() {
+			got = append(got, it.Log())
+		}
+		require.NoError(t, it.Err())
+		assert.Equal(t, logs, got)
+		assert.Equal(t, []uint64{4, 4}, orm.pageSizes)
+		assert.False(t, it.Next())
+	})
+
+	t.Run("bounds page size", func(t *testing.T) {
+		t.Parallel()
+		orm := &pagedORM{}
+		it := NewLogIterator(context.Background(), orm, nil, MaxIteratorPageSize+1, "")
+		require.False(t, it.Next())
+		it = NewLogIterator(context.Background(), orm, nil, 0, "")
+		require.False(t, it.Next())
+		assert.Equal(t, []uint64{MaxIteratorPageSize, DefaultIteratorPageSize}, orm.pageSizes)
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		it := NewLogIterator(ctx, &pagedORM{logs: logs}, nil, 2, "")
+		require.True(t, it.Next())
+		cancel()
+		require.False(t, it.Next())
+		require.ErrorIs(t, it.Err(), context.Canceled)
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		t.Parallel()
+		it := NewLogIterator(context.Background(), &pagedORM{err: errors.New("boom")}, nil, 2, "")
+		require.False(t, it.Next())
+		require.EqualError(t, it.Err(), "boom")
+	})
+}
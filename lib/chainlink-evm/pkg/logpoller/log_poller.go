@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand/v2"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -49,6 +50,8 @@ type LogPoller interface {
 	GetBlocksRange(ctx context.Context, numbers []uint64) ([]Block, error)
 	FindLCA(ctx context.Context) (*Block, error)
 	DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error
+	Reorgs(ctx context.Context, start, end int64) ([]Reorg, error)
+	FilterStats(ctx context.Context) ([]FilterStats, error)
 
 	// General querying
 	Logs(ctx context.Context, start, end int64, eventSig common.Hash, address common.Address) ([]Log, error)
@@ -72,6 +75,8 @@ type LogPoller interface {
 
 	// chainlink-common query filtering
 	FilteredLogs(ctx context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, queryName string) ([]Log, error)
+	AggregatedLogs(ctx context.Context, filter []query.Expression, aggregate Aggregate, queryName string) ([]AggregateResult, error)
+	IterateFilteredLogs(ctx context.Context, filter []query.Expression, pageSize uint64, queryName string) *LogIterator
 }
 
 type LogPollerTest interface {
@@ -79,6 +84,7 @@ type LogPollerTest interface {
 	PollAndSaveLogs(ctx context.Context, currentBlockNumber int64)
 	BackupPollAndSaveLogs(ctx context.Context) error
 	Filter(from, to *big.Int, bh *common.Hash) ethereum.FilterQuery
+	FilterQueries(from, to *big.Int, bh *common.Hash) []ethereum.FilterQuery
 	GetReplayFromBlock(ctx context.Context, requested int64) (int64, error)
 	PruneOldBlocks(ctx context.Context) (bool, error)
 }
@@ -106,27 +112,33 @@ var (
 
 type logPoller struct {
 	services.StateMachine
-	ec                       Client
-	orm                      ORM
-	headTracker              HeadTracker
-	latencyMonitor           LatencyMonitor
-	lggr                     logger.SugaredLogger
-	pollPeriod               time.Duration // poll period set by block production rate
-	useFinalityTag           bool          // indicates whether logPoller should use chain's finality or pick a fixed depth for finality
-	finalityDepth            int64         // finality depth is taken to mean that block (head - finality) is finalized. If `useFinalityTag` is set to true, this value is ignored, because finalityDepth is fetched from chain
-	keepFinalizedBlocksDepth int64         // the number of blocks behind the last finalized block we keep in database
-	backfillBatchSize        int64         // batch size to use when backfilling finalized logs
-	rpcBatchSize             int64         // batch size to use for fallback RPC calls made in GetBlocks
-	logPrunePageSize         int64
-	clientErrors             config.ClientErrors
-	backupPollerNextBlock    int64 // next block to be processed by Backup LogPoller
-	backupPollerBlockDelay   int64 // how far behind regular LogPoller should BackupLogPoller run. 0 = disabled
+	ec                           Client
+	orm                          ORM
+	headTracker                  HeadTracker
+	latencyMonitor               LatencyMonitor
+	lggr                         logger.SugaredLogger
+	pollPeriod                   time.Duration // poll period set by block production rate
+	useFinalityTag               bool          // indicates whether logPoller should use chain's finality or pick a fixed depth for finality
+	finalityDepth                int64         // finality depth is taken to mean that block (head - finality) is finalized. If `useFinalityTag` is set to true, this value is ignored, because finalityDepth is fetched from chain
+	keepFinalizedBlocksDepth     int64         // the number of blocks behind the last finalized block we keep in database
+	backfillBatchSize            int64         // batch size to use when backfilling finalized logs
+	rpcBatchSize                 int64         // batch size to use for fallback RPC calls made in GetBlocks
+	logPrunePageSize             int64
+	clientErrors                 config.ClientErrors
+	backupPollerNextBlock        int64         // next block to be processed by Backup LogPoller
+	backupPollerBlockDelay       int64         // how far behind regular LogPoller should BackupLogPoller run. 0 = disabled
+	autoRecoverFinalityViolation bool          // rewind to the deepest canonical block and backfill again whenever finality is violated
+	disableBloomFilter           bool          // fetch the logs of every block, even if its logs bloom matches no filter
+	reorgAuditRetention          time.Duration // how long to keep the history of handled reorgs. 0 = forever
+	filterStatsInterval          time.Duration // how often to refresh the filter stats gauges. 0 = disabled
 
 	filterMu        sync.RWMutex
 	filters         map[string]Filter
 	filterDirty     bool
 	cachedAddresses []common.Address
 	cachedEventSigs []common.Hash
+	cachedQueries   []ethereum.FilterQuery
+	cachedEnrichTx  map[common.Address]map[common.Hash]struct{}
 
 	replayStart    chan int64
 	replayComplete chan error
@@ -134,7 +146,7 @@ type logPoller struct {
 	wg             sync.WaitGroup
 	// This flag is raised whenever the log poller detects that the chain's finality has been violated.
 	// It can happen when reorg is deeper than the latest finalized block that LogPoller saw in a previous PollAndSave tick.
-	// Usually the only way to recover is to manually remove the offending logs and block from the database.
+	// Unless autoRecoverFinalityViolation is enabled, the only way to recover is to manually remove the offending logs and block from the database.
 	// LogPoller keeps running in infinite loop, so whenever the invalid state is removed from the database it should
 	// recover automatically without needing to restart the LogPoller.
 	finalityViolated           atomic.Bool
@@ -152,6 +164,13 @@ type Opts struct {
 	BackupPollerBlockDelay   int64
 	LogPrunePageSize         int64
 	ClientErrors             config.ClientErrors
+	// AutoRecoverFinalityViolation enables rewinding to the LCA and backfilling after a finality violation, see recoverFinalityViolation.
+	AutoRecoverFinalityViolation bool
+	// DisableBloomFilter fetches the logs of every block, for chains whose logs blooms can't be trusted, see bloomMayMatch.
+	DisableBloomFilter  bool
+	ReorgAuditRetention time.Duration
+	// FilterStatsInterval is how often the worker refreshes the filter stats gauges, see FilterStats. 0 disables it.
+	FilterStatsInterval time.Duration
 }
 
 // NewLogPoller creates a log poller. Note there is an assumption
@@ -166,25 +185,29 @@ type Opts struct {
 // support chain, polygon, which has 2s block times, we need RPCs roughly with <= 500ms latency
 func NewLogPoller(orm ORM, ec Client, lggr logger.Logger, headTracker HeadTracker, opts Opts) *logPoller {
 	return &logPoller{
-		stopCh:                   make(chan struct{}),
-		ec:                       ec,
-		orm:                      orm,
-		headTracker:              headTracker,
-		latencyMonitor:           NewLatencyMonitor(ec, lggr, opts.PollPeriod),
-		lggr:                     logger.Sugared(logger.Named(lggr, "LogPoller")),
-		replayStart:              make(chan int64),
-		replayComplete:           make(chan error),
-		pollPeriod:               opts.PollPeriod,
-		backupPollerBlockDelay:   opts.BackupPollerBlockDelay,
-		finalityDepth:            opts.FinalityDepth,
-		useFinalityTag:           opts.UseFinalityTag,
-		backfillBatchSize:        opts.BackfillBatchSize,
-		rpcBatchSize:             opts.RPCBatchSize,
-		keepFinalizedBlocksDepth: opts.KeepFinalizedBlocksDepth,
-		logPrunePageSize:         opts.LogPrunePageSize,
-		clientErrors:             opts.ClientErrors,
-		filters:                  make(map[string]Filter),
-		filterDirty:              true, // Always build Filter on first call to cache an empty filter if nothing registered yet.
+		stopCh:                       make(chan struct{}),
+		ec:                           ec,
+		orm:                          orm,
+		headTracker:                  headTracker,
+		latencyMonitor:               NewLatencyMonitor(ec, lggr, opts.PollPeriod),
+		lggr:                         logger.Sugared(logger.Named(lggr, "LogPoller")),
+		replayStart:                  make(chan int64),
+		replayComplete:               make(chan error),
+		pollPeriod:                   opts.PollPeriod,
+		backupPollerBlockDelay:       opts.BackupPollerBlockDelay,
+		finalityDepth:                opts.FinalityDepth,
+		useFinalityTag:               opts.UseFinalityTag,
+		backfillBatchSize:            opts.BackfillBatchSize,
+		rpcBatchSize:                 opts.RPCBatchSize,
+		keepFinalizedBlocksDepth:     opts.KeepFinalizedBlocksDepth,
+		logPrunePageSize:             opts.LogPrunePageSize,
+		clientErrors:                 opts.ClientErrors,
+		autoRecoverFinalityViolation: opts.AutoRecoverFinalityViolation,
+		disableBloomFilter:           opts.DisableBloomFilter,
+		reorgAuditRetention:          opts.ReorgAuditRetention,
+		filterStatsInterval:          opts.FilterStatsInterval,
+		filters:                      make(map[string]Filter),
+		filterDirty:                  true, // Always build Filter on first call to cache an empty filter if nothing registered yet.
 	}
 }
 
@@ -197,7 +220,75 @@ type Filter struct {
 	Topic4       evmtypes.HashArray // list of possible values for topic4
 	Retention    time.Duration      // maximum amount of time to retain logs
 	MaxLogsKept  uint64             // maximum number of logs to retain ( 0 = unlimited )
+	MaxBytesKept uint64             // maximum estimated storage size of logs to retain, in bytes ( 0 = unlimited )
 	LogsPerBlock uint64             // rate limit ( maximum # of logs per block, 0 = unlimited )
+	Tuples       []EventTuple       // explicit (address, eventSig, topics) combinations, not crossed with Addresses or EventSigs
+	EnrichTx     bool               // store sender, recipient, status and gas used of the transaction emitting each matched log
+}
+
+// EventTuple restricts a Filter to a single event emitted by a single contract, optionally narrowed down by topic values.
+// Unlike Filter.Addresses and Filter.EventSigs, tuples are never combined with each other, so a filter watching event A on
+// contract X and event B on contract Y doesn't also capture event B on contract X.
+type EventTuple struct {
+	Address  common.Address
+	EventSig common.Hash
+	Topic2   evmtypes.HashArray // list of possible values for topic2
+	Topic3   evmtypes.HashArray // list of possible values for topic3
+	Topic4   evmtypes.HashArray // list of possible values for topic4
+}
+
+// EventTuples returns every (address, eventSig) combination matched by the filter, i.e. the cross product of
+// Addresses and EventSigs followed by the explicit Tuples. The cross product entries carry no topic values, since
+// Topic2, Topic3 and Topic4 of the filter don't narrow down which logs are polled or kept.
+func (filter *Filter) EventTuples() []EventTuple {
+	tuples := make([]EventTuple, 0, len(filter.Addresses)*len(filter.EventSigs)+len(filter.Tuples))
+	for _, addr := range filter.Addresses {
+		for _, eventSig := range filter.EventSigs {
+			tuples = append(tuples, EventTuple{Address: addr, EventSig: eventSig})
+		}
+	}
+	return append(tuples, filter.Tuples...)
+}
+
+// topicValues returns the accepted values of topic2, topic3 and topic4, in that order.
+func (tuple EventTuple) topicValues() [3]evmtypes.HashArray {
+	return [3]evmtypes.HashArray{tuple.Topic2, tuple.Topic3, tuple.Topic4}
+}
+
+// covers returns true if every log matched by other is also matched by tuple.
+func (tuple EventTuple) covers(other EventTuple) bool {
+	if tuple.Address != other.Address || tuple.EventSig != other.EventSig {
+		return false
+	}
+	otherValues := other.topicValues()
+	for i, values := range tuple.topicValues() {
+		if len(values) == 0 {
+			continue
+		}
+		if len(otherValues[i]) == 0 {
+			return false
+		}
+		for _, v := range otherValues[i] {
+			if !slices.Contains(values, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesTopics returns true if the topics of a log, starting with its event sig, hold one of the accepted values
+// at every position the tuple restricts.
+func (tuple EventTuple) matchesTopics(topics [][]byte) bool {
+	for i, values := range tuple.topicValues() {
+		if len(values) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 || !slices.Contains(values, common.BytesToHash(topics[i+1])) {
+			return false
+		}
+	}
+	return true
 }
 
 // FilterName is a suggested convenience function for clients to construct unique filter names
@@ -228,6 +319,12 @@ func (filter *Filter) Contains(other *Filter) bool {
 	if other.MaxLogsKept != filter.MaxLogsKept {
 		return false
 	}
+	if other.MaxBytesKept != filter.MaxBytesKept {
+		return false
+	}
+	if other.EnrichTx != filter.EnrichTx {
+		return false
+	}
 	addresses := make(map[common.Address]interface{})
 	for _, addr := range filter.Addresses {
 		addresses[addr] = struct{}{}
@@ -247,6 +344,16 @@ func (filter *Filter) Contains(other *Filter) bool {
 			return false
 		}
 	}
+	if len(other.Tuples) == 0 {
+		return true
+	}
+
+	tuples := filter.EventTuples()
+	for _, tuple := range other.Tuples {
+		if !slices.ContainsFunc(tuples, func(t EventTuple) bool { return t.covers(tuple) }) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -261,16 +368,28 @@ func (filter *Filter) Contains(other *Filter) bool {
 //	RegisterFilter(event2, addr2)
 //
 // will result in the poller saving (event1, addr2) or (event2, addr1) as well, should it exist.
-// Generally speaking this is harmless. We enforce that EventSigs and Addresses are non-empty,
+// Generally speaking this is harmless. Filters that need to avoid the leakage can list explicit Filter.Tuples instead,
+// which are queried from the chain per address and pruned from the db unless the exact (address, event) pair is registered.
+// We enforce that EventSigs and Addresses (or Tuples) are non-empty,
 // which means that anonymous events are not supported and log.Topics >= 1 always (log.Topics[0] is the event signature).
 // The filter may be unregistered later by Filter.Name
 // Warnings/debug information is keyed by filter name.
 func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
-	if len(filter.Addresses) == 0 {
-		return pkgerrors.Errorf("at least one address must be specified")
+	if len(filter.Tuples) == 0 || len(filter.Addresses) != 0 || len(filter.EventSigs) != 0 {
+		if len(filter.Addresses) == 0 {
+			return pkgerrors.Errorf("at least one address must be specified")
+		}
+		if len(filter.EventSigs) == 0 {
+			return pkgerrors.Errorf("at least one event must be specified")
+		}
 	}
-	if len(filter.EventSigs) == 0 {
-		return pkgerrors.Errorf("at least one event must be specified")
+	for _, tuple := range filter.Tuples {
+		if tuple.EventSig == [common.HashLength]byte{} {
+			return pkgerrors.Errorf("empty event sig in tuple")
+		}
+		if tuple.Address == [common.AddressLength]byte{} {
+			return pkgerrors.Errorf("empty address in tuple")
+		}
 	}
 
 	for _, eventSig := range filter.EventSigs {
@@ -301,7 +420,7 @@ func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
 	}
 	lp.filters[filter.Name] = filter
 	lp.filterDirty = true
-	if filter.MaxLogsKept > 0 {
+	if filter.MaxLogsKept > 0 || filter.MaxBytesKept > 0 {
 		lp.countBasedLogPruningActive.Store(true)
 	}
 	return nil
@@ -353,63 +472,246 @@ func (lp *logPoller) GetFilters() map[string]Filter {
 			Topic4:       make(evmtypes.HashArray, len(v.Topic4)),
 			Retention:    v.Retention,
 			MaxLogsKept:  v.MaxLogsKept,
+			MaxBytesKept: v.MaxBytesKept,
 			LogsPerBlock: v.LogsPerBlock,
+			EnrichTx:     v.EnrichTx,
 		}
 		copy(deepCopyFilter.Addresses, v.Addresses)
 		copy(deepCopyFilter.EventSigs, v.EventSigs)
 		copy(deepCopyFilter.Topic2, v.Topic2)
 		copy(deepCopyFilter.Topic3, v.Topic3)
 		copy(deepCopyFilter.Topic4, v.Topic4)
+		if len(v.Tuples) > 0 {
+			deepCopyFilter.Tuples = make([]EventTuple, 0, len(v.Tuples))
+			for _, tuple := range v.Tuples {
+				deepCopyFilter.Tuples = append(deepCopyFilter.Tuples, EventTuple{
+					Address:  tuple.Address,
+					EventSig: tuple.EventSig,
+					Topic2:   append(evmtypes.HashArray{}, tuple.Topic2...),
+					Topic3:   append(evmtypes.HashArray{}, tuple.Topic3...),
+					Topic4:   append(evmtypes.HashArray{}, tuple.Topic4...),
+				})
+			}
+		}
 
 		filters[k] = deepCopyFilter
 	}
 	return filters
 }
 
+// Filter returns a single query merging the addresses and event sigs of all registered filters.
+// Since eth_getLogs takes the cross product of both, it may match more logs than any filter asked for;
+// the poller itself uses FilterQueries instead.
 func (lp *logPoller) Filter(from, to *big.Int, bh *common.Hash) ethereum.FilterQuery {
 	lp.filterMu.Lock()
 	defer lp.filterMu.Unlock()
-	if !lp.filterDirty {
-		return ethereum.FilterQuery{FromBlock: from, ToBlock: to, BlockHash: bh, Topics: [][]common.Hash{lp.cachedEventSigs}, Addresses: lp.cachedAddresses}
+	if lp.filterDirty {
+		lp.rebuildFilterCache()
+	}
+	return ethereum.FilterQuery{FromBlock: from, ToBlock: to, BlockHash: bh, Topics: [][]common.Hash{lp.cachedEventSigs}, Addresses: lp.cachedAddresses}
+}
+
+// FilterQueries returns the minimal set of queries matching exactly the (address, eventSig, topics) tuples of all registered
+// filters. Addresses interested in the same event sigs with the same topic values share a single query, so filters without
+// Tuples usually result in just one query.
+func (lp *logPoller) FilterQueries(from, to *big.Int, bh *common.Hash) []ethereum.FilterQuery {
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+	if lp.filterDirty {
+		lp.rebuildFilterCache()
+	}
+	queries := make([]ethereum.FilterQuery, 0, len(lp.cachedQueries))
+	for _, q := range lp.cachedQueries {
+		queries = append(queries, ethereum.FilterQuery{FromBlock: from, ToBlock: to, BlockHash: bh, Topics: q.Topics, Addresses: q.Addresses})
 	}
+	return queries
+}
+
+// anyTopics is the topicsKey of tuples which don't restrict topic values.
+var anyTopics = topicsKey([3]evmtypes.HashArray{})
+
+// rebuildFilterCache merges all registered filters into cached addresses, event sigs and per-address-group queries.
+// Must be called with filterMu held.
+func (lp *logPoller) rebuildFilterCache() {
 	var (
-		addressMp  = make(map[common.Address]struct{})
+		// Distinct topic values each address and event sig are watched for, by topicsKey.
+		addressMp  = make(map[common.Address]map[common.Hash]map[string][3]evmtypes.HashArray)
 		eventSigMp = make(map[common.Hash]struct{})
+		enrichMp   = make(map[common.Address]map[common.Hash]struct{})
 	)
 	// Merge filters.
 	for _, filter := range lp.filters {
-		for _, addr := range filter.Addresses {
-			addressMp[addr] = struct{}{}
-		}
-		for _, eventSig := range filter.EventSigs {
-			eventSigMp[eventSig] = struct{}{}
+		for _, tuple := range filter.EventTuples() {
+			if _, ok := addressMp[tuple.Address]; !ok {
+				addressMp[tuple.Address] = make(map[common.Hash]map[string][3]evmtypes.HashArray)
+			}
+			if _, ok := addressMp[tuple.Address][tuple.EventSig]; !ok {
+				addressMp[tuple.Address][tuple.EventSig] = make(map[string][3]evmtypes.HashArray)
+			}
+			var values [3]evmtypes.HashArray
+			for i, v := range tuple.topicValues() {
+				values[i] = slices.Compact(sortedHashes(slices.Clone(v)))
+			}
+			addressMp[tuple.Address][tuple.EventSig][topicsKey(values)] = values
+			eventSigMp[tuple.EventSig] = struct{}{}
+			if filter.EnrichTx {
+				if _, ok := enrichMp[tuple.Address]; !ok {
+					enrichMp[tuple.Address] = make(map[common.Hash]struct{})
+				}
+				enrichMp[tuple.Address][tuple.EventSig] = struct{}{}
+			}
 		}
 	}
-	addresses := make([]common.Address, 0, len(addressMp))
-	for addr := range addressMp {
-		addresses = append(addresses, addr)
+	addresses := sortedAddresses(maps.Keys(addressMp))
+	eventSigs := sortedHashes(maps.Keys(eventSigMp))
+
+	// Group addresses by the exact set of event sigs they are watched for with the same topic values.
+	var (
+		groupKeys []string
+		groups    = make(map[string]*ethereum.FilterQuery)
+	)
+	for _, addr := range addresses {
+		sigsByTopics := make(map[string][]common.Hash)
+		topicValues := make(map[string][3]evmtypes.HashArray)
+		for _, sig := range sortedHashes(maps.Keys(addressMp[addr])) {
+			specs := addressMp[addr][sig]
+			if values, ok := specs[anyTopics]; ok {
+				// Any topics already match every other set of topic values.
+				specs = map[string][3]evmtypes.HashArray{anyTopics: values}
+			}
+			for tk, values := range specs {
+				sigsByTopics[tk] = append(sigsByTopics[tk], sig)
+				topicValues[tk] = values
+			}
+		}
+		tks := maps.Keys(sigsByTopics)
+		slices.Sort(tks)
+		for _, tk := range tks {
+			sigs := sigsByTopics[tk]
+			var key strings.Builder
+			key.WriteString(tk)
+			for _, sig := range sigs {
+				key.Write(sig[:])
+			}
+			q, ok := groups[key.String()]
+			if !ok {
+				q = &ethereum.FilterQuery{Topics: queryTopics(sigs, topicValues[tk])}
+				groups[key.String()] = q
+				groupKeys = append(groupKeys, key.String())
+			}
+			q.Addresses = append(q.Addresses, addr)
+		}
 	}
-	sort.Slice(addresses, func(i, j int) bool {
-		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
-	})
-	eventSigs := make([]common.Hash, 0, len(eventSigMp))
-	for eventSig := range eventSigMp {
-		eventSigs = append(eventSigs, eventSig)
+	queries := make([]ethereum.FilterQuery, 0, len(groupKeys))
+	for _, key := range groupKeys {
+		queries = append(queries, *groups[key])
 	}
-	sort.Slice(eventSigs, func(i, j int) bool {
-		return bytes.Compare(eventSigs[i][:], eventSigs[j][:]) < 0
-	})
+
 	if len(eventSigs) == 0 && len(addresses) == 0 {
 		// If no filter specified, ignore everything.
 		// This allows us to keep the log poller up and running with no filters present (e.g. no jobs on the node),
 		// then as jobs are added dynamically start using their filters.
 		addresses = []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000000")}
 		eventSigs = []common.Hash{}
+		queries = []ethereum.FilterQuery{{Topics: [][]common.Hash{eventSigs}, Addresses: addresses}}
 	}
 	lp.cachedAddresses = addresses
 	lp.cachedEventSigs = eventSigs
+	lp.cachedQueries = queries
+	lp.cachedEnrichTx = enrichMp
 	lp.filterDirty = false
-	return ethereum.FilterQuery{FromBlock: from, ToBlock: to, BlockHash: bh, Topics: [][]common.Hash{eventSigs}, Addresses: addresses}
+}
+
+// topicsKey identifies a set of sorted topic values.
+func topicsKey(values [3]evmtypes.HashArray) string {
+	var key strings.Builder
+	for _, v := range values {
+		for _, h := range v {
+			key.Write(h[:])
+		}
+		key.WriteByte('|')
+	}
+	return key.String()
+}
+
+// queryTopics returns the eth_getLogs topics matching any of sigs with the given topic values, omitting trailing wildcards.
+func queryTopics(sigs []common.Hash, values [3]evmtypes.HashArray) [][]common.Hash {
+	topics := [][]common.Hash{sigs}
+	for i, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+		for len(topics) < 1+i {
+			topics = append(topics, nil)
+		}
+		topics = append(topics, v)
+	}
+	return topics
+}
+
+// bloomMayMatch returns false only if bloom is known and contains none of the registered addresses or none of the
+// registered event sigs, in which case the block can't hold any log matching a filter. An empty bloom is treated as
+// unknown, since some RPCs return a zero bloom instead of omitting it.
+func (lp *logPoller) bloomMayMatch(bloom *types.Bloom) bool {
+	if lp.disableBloomFilter || bloom == nil || *bloom == (types.Bloom{}) {
+		return true
+	}
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+	if lp.filterDirty {
+		lp.rebuildFilterCache()
+	}
+	return slices.ContainsFunc(lp.cachedAddresses, func(addr common.Address) bool { return bloom.Test(addr.Bytes()) }) &&
+		slices.ContainsFunc(lp.cachedEventSigs, func(sig common.Hash) bool { return bloom.Test(sig.Bytes()) })
+}
+
+func sortedAddresses(addresses []common.Address) []common.Address {
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	return addresses
+}
+
+func sortedHashes(hashes []common.Hash) []common.Hash {
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	return hashes
+}
+
+// filterLogs runs all queries and returns their combined results ordered by block number and log index.
+// Logs matched by more than one query, e.g. by tuples accepting overlapping topic values, are returned once.
+func (lp *logPoller) filterLogs(ctx context.Context, queries []ethereum.FilterQuery) ([]types.Log, error) {
+	if len(queries) == 1 {
+		return lp.latencyMonitor.FilterLogs(ctx, queries[0])
+	}
+	type logID struct {
+		blockHash common.Hash
+		index     uint
+	}
+	var logs []types.Log
+	seen := make(map[logID]struct{})
+	for _, q := range queries {
+		result, err := lp.latencyMonitor.FilterLogs(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range result {
+			id := logID{l.BlockHash, l.Index}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			logs = append(logs, l)
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	return logs, nil
 }
 
 // Replay signals that the poller should resume from a new block.
@@ -579,7 +881,7 @@ func (lp *logPoller) loadFilters(ctx context.Context) error {
 		return nil
 	}
 	for _, filter := range filters {
-		if filter.MaxLogsKept != 0 {
+		if filter.MaxLogsKept != 0 || filter.MaxBytesKept != 0 {
 			lp.countBasedLogPruningActive.Store(true)
 			return nil
 		}
@@ -640,6 +942,13 @@ func (lp *logPoller) run() {
 				filtersLoaded = true
 			}
 
+			if lp.autoRecoverFinalityViolation && lp.finalityViolated.Load() {
+				if err := lp.recoverFinalityViolation(ctx); err != nil {
+					lp.lggr.Errorw("Failed to recover from finality violation, retrying later", "err", err)
+					continue
+				}
+			}
+
 			// Always start from the latest block in the db.
 			var start int64
 			lastProcessed, err := lp.orm.SelectLatestBlock(ctx)
@@ -708,6 +1017,11 @@ func (lp *logPoller) backgroundWorkerRun() {
 	// Deferring first prune by at least 5 mins reduces risk of putting too much pressure on the database.
 	blockPruneTick := tickStaggeredDelay(5*time.Minute, blockPruneInterval)
 	logPruneTick := tickStaggeredDelay(5*time.Minute, logPruneInterval)
+	// Filter stats size every stored log, so they're refreshed on their own, much slower schedule.
+	var filterStatsTick <-chan time.Time
+	if lp.filterStatsInterval > 0 {
+		filterStatsTick = tickStaggeredDelay(5*time.Minute, lp.filterStatsInterval)
+	}
 
 	// Start initial prune of unmatched logs after 5-15 successful expired log prunes, so that not all chains start
 	// around the same time. After that, every 20 successful expired log prunes.
@@ -720,6 +1034,11 @@ func (lp *logPoller) backgroundWorkerRun() {
 		case <-blockPruneTick:
 			lp.lggr.Infow("pruning old blocks")
 			blockPruneTick = tickWithDefaultJitter(blockPruneInterval)
+			if removed, err := lp.orm.DeleteExpiredReorgs(ctx, lp.reorgAuditRetention); err != nil {
+				lp.lggr.Errorw("unable to prune expired reorg history", "err", err)
+			} else if removed > 0 {
+				lp.lggr.Debugw("pruned expired reorg history", "removed", removed)
+			}
 			if allRemoved, err := lp.PruneOldBlocks(ctx); err != nil {
 				lp.lggr.Errorw("unable to prune old blocks", "err", err)
 			} else if !allRemoved {
@@ -755,6 +1074,11 @@ func (lp *logPoller) backgroundWorkerRun() {
 				lp.lggr.Debugw("finished pruning expired logs")
 				successfulExpiredLogPrunes++
 			}
+		case <-filterStatsTick:
+			filterStatsTick = tickWithDefaultJitter(lp.filterStatsInterval)
+			if _, err := lp.FilterStats(ctx); err != nil {
+				lp.lggr.Errorw("unable to refresh filter stats", "err", err)
+			}
 		}
 	}
 }
@@ -904,7 +1228,7 @@ func (lp *logPoller) backfill(ctx context.Context, start, end int64) error {
 	for from := start; from <= end; from += batchSize {
 		to := mathutil.Min(from+batchSize-1, end)
 
-		gethLogs, err := lp.latencyMonitor.FilterLogs(ctx, lp.Filter(big.NewInt(from), big.NewInt(to), nil))
+		gethLogs, err := lp.filterLogs(ctx, lp.FilterQueries(big.NewInt(from), big.NewInt(to), nil))
 		if err != nil {
 			if client.IsMissingBlocks(err, lp.clientErrors) {
 				errCount := lp.missingBlocksErrorCount.Add(1)
@@ -943,8 +1267,14 @@ func (lp *logPoller) backfill(ctx context.Context, start, end int64) error {
 			blocks = blocks[:len(blocks)-1]
 		}
 
+		logs := convertLogs(gethLogs, blocks, lp.lggr, lp.ec.ConfiguredChainID())
+		if err = lp.enrichLogs(ctx, logs); err != nil {
+			lp.lggr.Warnw("Unable to enrich logs, retrying", "err", err, "from", from, "to", to)
+			return err
+		}
+
 		lp.lggr.Debugw("Inserting backfilled logs with batch endblock", "from", from, "to", to, "logs", len(gethLogs), "blocks", blocks)
-		err = lp.orm.InsertLogsWithBlock(ctx, convertLogs(gethLogs, blocks, lp.lggr, lp.ec.ConfiguredChainID()), endblock)
+		err = lp.orm.InsertLogsWithBlock(ctx, logs, endblock)
 		if err != nil {
 			lp.lggr.Warnw("Unable to insert logs, retrying", "err", err, "from", from, "to", to)
 			return err
@@ -1006,13 +1336,12 @@ func (lp *logPoller) getCurrentBlockMaybeHandleReorg(ctx context.Context, curren
 
 		lp.lggr.Infow("Reorg detected", "blockAfterLCA", blockAfterLCA.Number, "currentBlockNumber", currentBlockNumber)
 		// We truncate all the blocks and logs after the LCA.
-		// We could preserve the logs for forensics, since its possible
-		// that applications see them and take action upon it, however that
-		// results in significantly slower reads since we must then compute
-		// the canonical set per read. Typically, if an application took action on a log
+		// Keeping the reorged logs next to the canonical ones would result in significantly slower reads,
+		// since we must then compute the canonical set per read. Typically, if an application took action on a log
 		// it would be saved elsewhere e.g. evm.txes, so it seems better to just support the fast reads.
 		// Its also nicely analogous to reading from the chain itself.
-		err2 = lp.orm.DeleteLogsAndBlocksAfter(ctx, blockAfterLCA.Number)
+		// For forensics, the replaced block hashes and removed logs are moved to the reorg history, see Reorgs.
+		err2 = lp.orm.DeleteReorgedLogsAndBlocksAfter(ctx, blockAfterLCA.Number)
 		if err2 != nil {
 			// If we error on db commit, we can't know if the tx went through or not.
 			// We return an error here which will cause us to restart polling from lastBlockSaved + 1
@@ -1114,10 +1443,14 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 
 		h := currentBlock.Hash
 		var logs []types.Log
-		logs, err = lp.latencyMonitor.FilterLogs(ctx, lp.Filter(nil, nil, &h))
-		if err != nil {
-			lp.lggr.Warnw("Unable to query for logs, retrying", "err", err, "block", currentBlockNumber)
-			return nil
+		if lp.bloomMayMatch(currentBlock.LogsBloom) {
+			logs, err = lp.filterLogs(ctx, lp.FilterQueries(nil, nil, &h))
+			if err != nil {
+				lp.lggr.Warnw("Unable to query for logs, retrying", "err", err, "block", currentBlockNumber)
+				return nil
+			}
+		} else {
+			promLpBloomSkippedBlocks.WithLabelValues(lp.ec.ConfiguredChainID().String()).Inc()
 		}
 		lp.lggr.Debugw("Unfinalized log query", "logs", len(logs), "currentBlockNumber", currentBlockNumber, "blockHash", currentBlock.Hash, "timestamp", currentBlock.Timestamp)
 		block := Block{
@@ -1127,11 +1460,12 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 			FinalizedBlockNumber: latestFinalizedBlockNumber,
 			SafeBlockNumber:      safeBlockNumber,
 		}
-		err = lp.orm.InsertLogsWithBlock(
-			ctx,
-			convertLogs(logs, []Block{block}, lp.lggr, lp.ec.ConfiguredChainID()),
-			block,
-		)
+		lpLogs := convertLogs(logs, []Block{block}, lp.lggr, lp.ec.ConfiguredChainID())
+		if err = lp.enrichLogs(ctx, lpLogs); err != nil {
+			lp.lggr.Warnw("Unable to enrich logs, retrying", "err", err, "block", currentBlockNumber)
+			return nil
+		}
+		err = lp.orm.InsertLogsWithBlock(ctx, lpLogs, block)
 		if err != nil {
 			lp.lggr.Warnw("Unable to save logs resuming from last saved block + 1", "err", err, "block", currentBlockNumber)
 			return nil
@@ -1265,6 +1599,7 @@ func (lp *logPoller) PruneExpiredLogs(ctx context.Context) (bool, error) {
 	} else if lp.logPrunePageSize != 0 && rowsRemoved == lp.logPrunePageSize {
 		done = false
 	}
+	promLpPrunedLogs.WithLabelValues(lp.ec.ConfiguredChainID().String(), "expired").Add(float64(rowsRemoved))
 
 	if !lp.countBasedLogPruningActive.Load() {
 		return done, err
@@ -1281,6 +1616,7 @@ func (lp *logPoller) PruneExpiredLogs(ctx context.Context) (bool, error) {
 	} else if lp.logPrunePageSize != 0 && rowsRemoved == lp.logPrunePageSize {
 		done = false
 	}
+	promLpPrunedLogs.WithLabelValues(lp.ec.ConfiguredChainID().String(), "excess").Add(float64(rowsRemoved))
 	return done, err
 }
 
@@ -1292,6 +1628,7 @@ func (lp *logPoller) PruneUnmatchedLogs(ctx context.Context) (bool, error) {
 		return false, err
 	}
 	rowsRemoved, err := lp.orm.DeleteLogsByRowID(ctx, ids)
+	promLpPrunedLogs.WithLabelValues(lp.ec.ConfiguredChainID().String(), "unmatched").Add(float64(rowsRemoved))
 
 	return lp.logPrunePageSize == 0 || rowsRemoved < lp.logPrunePageSize, err
 }
@@ -1670,6 +2007,79 @@ func (lp *logPoller) DeleteLogsAndBlocksAfter(ctx context.Context, start int64)
 	return lp.orm.DeleteLogsAndBlocksAfter(ctx, start)
 }
 
+// recoverFinalityViolation rewinds LogPoller's state after a finality violation. It locates the deepest block whose hash
+// still matches the chain with FindLCA, which is not limited by the finalized boundary, and deletes all logs and blocks
+// after it. The main loop then backfills from LCA+1, clearing finalityViolated once polling succeeds again.
+// Must only be called from the main loop, so that it does not race with block and log inserts.
+func (lp *logPoller) recoverFinalityViolation(ctx context.Context) error {
+	latest, err := lp.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to select the latest block: %w", err)
+	}
+
+	lca, err := lp.FindLCA(ctx)
+	if err != nil {
+		lp.recordFinalityRecovery(ctx, FinalityRecoveryFailed, nil, latest, err.Error())
+		return err
+	}
+	lp.recordFinalityRecovery(ctx, FinalityRecoveryLCAFound, lca, latest, "")
+	lp.lggr.Criticalw("Recovering from finality violation, removing all logs and blocks after LCA",
+		"lcaBlockNumber", lca.BlockNumber, "lcaBlockHash", lca.BlockHash, "latestBlockNumber", latest.BlockNumber)
+
+	if err = lp.orm.DeleteLogsAndBlocksAfter(ctx, lca.BlockNumber+1); err != nil {
+		lp.recordFinalityRecovery(ctx, FinalityRecoveryFailed, lca, latest, err.Error())
+		return fmt.Errorf("failed to delete logs and blocks after LCA %d: %w", lca.BlockNumber, err)
+	}
+	lp.recordFinalityRecovery(ctx, FinalityRecoveryRewound, lca, latest, "")
+
+	// Backup poller might have already moved past the LCA, make sure it re-processes the rewound range as well.
+	if lp.backupPollerNextBlock > lca.BlockNumber+1 {
+		lp.backupPollerNextBlock = lca.BlockNumber + 1
+	}
+	return nil
+}
+
+// recordFinalityRecovery persists a single step of recoverFinalityViolation in the audit table and bumps the metric.
+// Failing to record is logged, but doesn't stop the recovery.
+func (lp *logPoller) recordFinalityRecovery(ctx context.Context, action FinalityRecoveryAction, lca *Block, latest *Block, reason string) {
+	chainID := lp.ec.ConfiguredChainID()
+	promLpFinalityRecoveries.WithLabelValues(chainID.String(), string(action)).Inc()
+	lp.lggr.Errorw("Finality violation recovery", "action", action, "reason", reason)
+
+	recovery := FinalityRecovery{
+		EVMChainID:        ubig.New(chainID),
+		Action:            action,
+		LatestBlockNumber: latest.BlockNumber,
+		LatestBlockHash:   latest.BlockHash,
+		Reason:            reason,
+	}
+	if lca != nil {
+		recovery.LCABlockNumber = lca.BlockNumber
+		recovery.LCABlockHash = lca.BlockHash
+	}
+	if err := lp.orm.InsertFinalityRecovery(ctx, recovery); err != nil {
+		lp.lggr.Errorw("Failed to record finality violation recovery", "action", action, "err", err)
+	}
+}
+
+// Reorgs returns the history of reorgs that replaced at least one block in the range [start, end].
+// Each Reorg lists the replaced block hashes and the logs which were removed from the database as a result.
+func (lp *logPoller) Reorgs(ctx context.Context, start, end int64) ([]Reorg, error) {
+	return lp.orm.SelectReorgs(ctx, start, end)
+}
+
+// FilterStats returns how many logs each registered filter currently stores, the block range they span and an estimate
+// of their size, and publishes the same values as Prometheus gauges. Logs matched by several filters count towards each.
+// Sizing the logs scans all of them, so the background worker only calls it every Opts.FilterStatsInterval.
+func (lp *logPoller) FilterStats(ctx context.Context) ([]FilterStats, error) {
+	stats, err := lp.orm.SelectFilterStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setFilterStatsMetrics(lp.ec.ConfiguredChainID(), stats)
+	return stats, nil
+}
+
 func (lp *logPoller) FindLCA(ctx context.Context) (*Block, error) {
 	latest, err := lp.orm.SelectLatestBlock(ctx)
 	if err != nil {
@@ -1772,6 +2182,16 @@ func (lp *logPoller) FilteredLogs(ctx context.Context, queryFilter []query.Expre
 	return lp.orm.FilteredLogs(ctx, queryFilter, limitAndSort, queryName)
 }
 
+// IterateFilteredLogs streams all logs matching filter in ascending (block_number, log_index) order, fetching at most
+// pageSize logs at a time. See LogIterator.
+func (lp *logPoller) IterateFilteredLogs(ctx context.Context, queryFilter []query.Expression, pageSize uint64, queryName string) *LogIterator {
+	return NewLogIterator(ctx, lp.orm, queryFilter, pageSize, queryName)
+}
+
+func (lp *logPoller) AggregatedLogs(ctx context.Context, queryFilter []query.Expression, aggregate Aggregate, queryName string) ([]AggregateResult, error) {
+	return lp.orm.AggregatedLogs(ctx, queryFilter, aggregate, queryName)
+}
+
 // Where is a query.Where wrapper that ignores the Key and returns a slice of query.Expression rather than query.KeyFilter.
 // If no expressions are provided, or an error occurs, an empty slice is returned.
 func Where(expressions ...query.Expression) ([]query.Expression, error) {
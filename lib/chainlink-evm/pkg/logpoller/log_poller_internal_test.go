@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -146,6 +147,171 @@ func TestLogPoller_RegisterFilter(t *testing.T) {
 	assert.Empty(t, lp.Filter(nil, nil, nil).Topics[0])
 }
 
+func TestLogPoller_FilterQueries(t *testing.T) {
+	t.Parallel()
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	a3 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbd")
+	log1 := EmitterABI.Events["Log1"].ID
+	log2 := EmitterABI.Events["Log2"].ID
+
+	lp := NewLogPoller(nil, nil, logger.Test(t), nil, Opts{PollPeriod: time.Hour})
+	lp.filters = map[string]Filter{
+		"cross product": {Name: "cross product", Addresses: []common.Address{a1, a3}, EventSigs: []common.Hash{log1}},
+		"tuples": {Name: "tuples", Tuples: []EventTuple{
+			{Address: a2, EventSig: log2},
+			{Address: a3, EventSig: log1},
+		}},
+	}
+	lp.filterDirty = true
+
+	from, to := big.NewInt(10), big.NewInt(20)
+	queries := lp.FilterQueries(from, to, nil)
+	require.Len(t, queries, 2)
+	assert.Equal(t, []common.Address{a1, a3}, queries[0].Addresses)
+	assert.Equal(t, [][]common.Hash{{log1}}, queries[0].Topics)
+	assert.Equal(t, []common.Address{a2}, queries[1].Addresses)
+	assert.Equal(t, [][]common.Hash{{log2}}, queries[1].Topics)
+	for _, q := range queries {
+		assert.Equal(t, from, q.FromBlock)
+		assert.Equal(t, to, q.ToBlock)
+	}
+
+	// The merged filter still covers everything, at the cost of matching (a1, log2) and (a2, log1) too.
+	f := lp.Filter(nil, nil, nil)
+	assert.Equal(t, []common.Address{a1, a2, a3}, f.Addresses)
+	assert.Len(t, f.Topics[0], 2)
+
+	// Tuples only match the topic values they ask for, unless the same address and event sig is watched for any topics.
+	topicA, topicB := common.HexToHash("0xa"), common.HexToHash("0xb")
+	lp.filters = map[string]Filter{
+		"cross product": {Name: "cross product", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log1}},
+		"topics": {Name: "topics", Tuples: []EventTuple{
+			{Address: a1, EventSig: log1, Topic2: []common.Hash{topicA}},
+			{Address: a2, EventSig: log1, Topic3: []common.Hash{topicB, topicA}},
+			{Address: a3, EventSig: log1, Topic3: []common.Hash{topicA, topicB}},
+		}},
+	}
+	lp.filterDirty = true
+	queries = lp.FilterQueries(nil, nil, nil)
+	require.Len(t, queries, 2)
+	assert.Equal(t, []common.Address{a1}, queries[0].Addresses)
+	assert.Equal(t, [][]common.Hash{{log1}}, queries[0].Topics)
+	assert.Equal(t, []common.Address{a2, a3}, queries[1].Addresses)
+	assert.Equal(t, [][]common.Hash{{log1}, nil, {topicA, topicB}}, queries[1].Topics)
+
+	lp.filters["other topics"] = Filter{Name: "other topics", Tuples: []EventTuple{{Address: a3, EventSig: log2, Topic2: []common.Hash{topicA}}}}
+	lp.filterDirty = true
+	queries = lp.FilterQueries(nil, nil, nil)
+	require.Len(t, queries, 3)
+	assert.Equal(t, []common.Address{a2, a3}, queries[1].Addresses)
+	assert.Equal(t, []common.Address{a3}, queries[2].Addresses)
+	assert.Equal(t, [][]common.Hash{{log2}, {topicA}}, queries[2].Topics)
+
+	lp.filters = map[string]Filter{}
+	lp.filterDirty = true
+	queries = lp.FilterQueries(nil, nil, nil)
+	require.Len(t, queries, 1)
+	assert.Equal(t, []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000000")}, queries[0].Addresses)
+}
+
+func TestLogPoller_EnrichLogs(t *testing.T) {
+	t.Parallel()
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	log1 := EmitterABI.Events["Log1"].ID
+	tx1, tx2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+	sender, target := common.HexToAddress("0x5"), common.HexToAddress("0x6")
+
+	ec := clienttest.NewClient(t)
+	ec.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		reqs := args.Get(1).([]rpc.BatchElem)
+		require.Len(t, reqs, 1)
+		assert.Equal(t, "eth_getTransactionReceipt", reqs[0].Method)
+		assert.Equal(t, []any{tx1}, reqs[0].Args)
+		*reqs[0].Result.(**txEnrichment) = &txEnrichment{From: sender, To: &target, Status: 1, GasUsed: 21000}
+	}).Once()
+
+	lp := NewLogPoller(nil, ec, logger.Test(t), nil, Opts{PollPeriod: time.Hour, RPCBatchSize: 10})
+	lp.filters = map[string]Filter{
+		"enriched": {Name: "enriched", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log1}, EnrichTx: true},
+		"plain":    {Name: "plain", Addresses: []common.Address{a2}, EventSigs: []common.Hash{log1}},
+	}
+	lp.filterDirty = true
+
+	logs := []Log{
+		{Address: a1, EventSig: log1, TxHash: tx1, LogIndex: 0},
+		{Address: a1, EventSig: log1, TxHash: tx1, LogIndex: 1},
+		{Address: a2, EventSig: log1, TxHash: tx2, LogIndex: 2},
+	}
+	require.NoError(t, lp.enrichLogs(testutils.Context(t), logs))
+	for _, l := range logs[:2] {
+		require.NotNil(t, l.TxFrom)
+		assert.Equal(t, sender, *l.TxFrom)
+		assert.Equal(t, target, *l.TxTo)
+		assert.Equal(t, uint64(1), *l.TxStatus)
+		assert.Equal(t, uint64(21000), *l.TxGasUsed)
+	}
+	assert.Nil(t, logs[2].TxFrom)
+	assert.Nil(t, logs[2].TxStatus)
+
+	ec.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Once()
+	err := lp.enrichLogs(testutils.Context(t), []Log{{Address: a1, EventSig: log1, TxHash: tx2}})
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestLogPoller_BloomMayMatch(t *testing.T) {
+	t.Parallel()
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	log1 := EmitterABI.Events["Log1"].ID
+	log2 := EmitterABI.Events["Log2"].ID
+
+	lp := NewLogPoller(nil, nil, logger.Test(t), nil, Opts{PollPeriod: time.Hour})
+	lp.filters = map[string]Filter{
+		"filter": {Name: "filter", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log1}},
+	}
+	lp.filterDirty = true
+
+	bloomOf := func(logs ...*types.Log) *types.Bloom {
+		bloom := types.CreateBloom(&types.Receipt{Logs: logs})
+		return &bloom
+	}
+
+	assert.True(t, lp.bloomMayMatch(nil), "unknown bloom must be queried")
+	assert.True(t, lp.bloomMayMatch(bloomOf(&types.Log{Address: a1, Topics: []common.Hash{log1}})))
+	assert.True(t, lp.bloomMayMatch(bloomOf()), "empty bloom must be queried")
+	assert.False(t, lp.bloomMayMatch(bloomOf(&types.Log{Address: a2, Topics: []common.Hash{log1}})))
+	assert.False(t, lp.bloomMayMatch(bloomOf(&types.Log{Address: a1, Topics: []common.Hash{log2}})))
+
+	lp.disableBloomFilter = true
+	assert.True(t, lp.bloomMayMatch(bloomOf(&types.Log{Address: a2, Topics: []common.Hash{log2}})), "bloom filter disabled")
+}
+
+func TestFilter_Contains_Tuples(t *testing.T) {
+	t.Parallel()
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	log1 := EmitterABI.Events["Log1"].ID
+	log2 := EmitterABI.Events["Log2"].ID
+
+	crossProduct := Filter{Addresses: []common.Address{a1, a2}, EventSigs: []common.Hash{log1, log2}}
+	tuples := Filter{Tuples: []EventTuple{{Address: a1, EventSig: log1}, {Address: a2, EventSig: log2}}}
+	assert.True(t, crossProduct.Contains(&tuples))
+	assert.True(t, tuples.Contains(&tuples))
+	assert.False(t, tuples.Contains(&Filter{Tuples: []EventTuple{{Address: a1, EventSig: log2}}}))
+	assert.Len(t, crossProduct.EventTuples(), 4)
+
+	topicA, topicB := common.HexToHash("0xa"), common.HexToHash("0xb")
+	narrow := Filter{Tuples: []EventTuple{{Address: a1, EventSig: log1, Topic2: []common.Hash{topicA}}}}
+	wide := Filter{Tuples: []EventTuple{{Address: a1, EventSig: log1, Topic2: []common.Hash{topicB, topicA}}}}
+	assert.True(t, tuples.Contains(&narrow), "any topics contain specific ones")
+	assert.True(t, wide.Contains(&narrow))
+	assert.False(t, narrow.Contains(&wide))
+	assert.False(t, narrow.Contains(&tuples), "specific topics don't contain any topics")
+	assert.False(t, narrow.Contains(&Filter{Tuples: []EventTuple{{Address: a1, EventSig: log1, Topic3: []common.Hash{topicA}}}}))
+}
+
 func TestLogPoller_ConvertLogs(t *testing.T) {
 	t.Parallel()
 	lggr := logger.Test(t)
@@ -833,6 +999,46 @@ func Test_PollAndSaveLogs_BackfillFinalityViolation(t *testing.T) {
 	})
 }
 
+func Test_RecoverFinalityViolation(t *testing.T) {
+	t.Parallel()
+
+	db := testutils.NewSqlxDB(t)
+	lggr, _ := logger.TestObserved(t, zapcore.ErrorLevel)
+	chainID := testutils.NewRandomEVMChainID()
+	orm := NewORM(chainID, db, lggr)
+	ctx := testutils.Context(t)
+
+	// Blocks 1-5 are still canonical, 6-10 were reorged out of the chain despite being finalized.
+	for i := int64(1); i <= 10; i++ {
+		hash := common.BigToHash(big.NewInt(i))
+		if i > 5 {
+			hash = utils.NewHash()
+		}
+		require.NoError(t, orm.InsertBlock(ctx, hash, i, time.Unix(i, 0), i, i))
+	}
+	ec := clienttest.NewClient(t)
+	ec.EXPECT().ConfiguredChainID().Return(chainID)
+	ec.EXPECT().HeadByHash(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, hash common.Hash) (*evmtypes.Head, error) {
+		n := hash.Big().Int64()
+		if n < 1 || n > 5 {
+			return nil, ethereum.NotFound
+		}
+		return newHead(n), nil
+	})
+
+	lp := NewLogPoller(orm, ec, lggr, nil, Opts{PollPeriod: time.Second, AutoRecoverFinalityViolation: true})
+	lp.finalityViolated.Store(true)
+	lp.backupPollerNextBlock = 9
+	require.NoError(t, lp.recoverFinalityViolation(ctx))
+
+	latest, err := orm.SelectLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), latest.BlockNumber)
+	assert.Equal(t, int64(6), lp.backupPollerNextBlock)
+	// Flag is cleared only after the rewound range is polled again.
+	assert.True(t, lp.finalityViolated.Load())
+}
+
 func benchmarkFilter(b *testing.B, nFilters, nAddresses, nEvents int) {
 	lggr := logger.Test(b)
 	lpOpts := Opts{
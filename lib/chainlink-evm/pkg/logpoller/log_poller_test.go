@@ -1,12 +1,14 @@
 package logpoller_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -2153,3 +2155,92 @@ func TestWhere(t *testing.T) {
 		assert.Equal(t, []query.Expression{}, result)
 	})
 }
+
+func TestLogPoller_Archive(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	th := SetupTH(t, lpOpts)
+	filter := logpoller.Filter{Name: "archive", EventSigs: []common.Hash{EmitterABI.Events["Log1"].ID}, Addresses: []common.Address{th.EmitterAddress1}}
+	require.NoError(t, th.LogPoller.RegisterFilter(ctx, filter))
+	for i := 0; i < 3; i++ {
+		_, err := th.Emitter1.EmitLog1(th.Owner, []*big.Int{big.NewInt(int64(i))})
+		require.NoError(t, err)
+		th.Backend.Commit()
+	}
+	th.PollAndSaveLogs(ctx, 1)
+	latest, err := th.LogPoller.LatestBlock(ctx)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	stats, err := logpoller.ExportArchive(ctx, th.ORM, &buf, logpoller.ArchiveOpts{Format: logpoller.ArchiveFormatJSONL, Start: 1, End: latest.BlockNumber})
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Logs)
+	archive := buf.String()
+
+	importArchive := func(t *testing.T, archive string, prepare func(o *logpoller.DSORM)) (*logpoller.DSORM, logpoller.ArchiveStats, error) {
+		// a separate database, since the archive is imported in a transaction, and holds the same rows as th.ORM
+		o := logpoller.NewORM(th.ChainID, testutils.NewIndependentSqlxDB(t), th.Lggr)
+		require.NoError(t, o.InsertFilter(ctx, filter))
+		if prepare != nil {
+			prepare(o)
+		}
+		stats, err := logpoller.ImportArchive(ctx, o, th.Client, strings.NewReader(archive), logpoller.ArchiveFormatJSONL)
+		return o, stats, err
+	}
+	assertEmpty := func(t *testing.T, o *logpoller.DSORM) {
+		logs, err := o.SelectLogsByBlockRange(ctx, 1, latest.BlockNumber)
+		require.NoError(t, err)
+		assert.Empty(t, logs)
+	}
+
+	t.Run("imports blocks and logs", func(t *testing.T) {
+		o, imported, err := importArchive(t, archive, nil)
+		require.NoError(t, err)
+		assert.Equal(t, stats, imported)
+		logs, err := o.SelectLogsByBlockRange(ctx, 1, latest.BlockNumber)
+		require.NoError(t, err)
+		assert.Len(t, logs, 3)
+		block, err := o.SelectLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latest.BlockHash, block.BlockHash)
+	})
+
+	t.Run("imports through an observed ORM", func(t *testing.T) {
+		o, err := logpoller.NewObservedORM(th.ChainID, testutils.NewIndependentSqlxDB(t), th.Lggr)
+		require.NoError(t, err)
+		require.NoError(t, o.InsertFilter(ctx, filter))
+		imported, err := logpoller.ImportArchive(ctx, o, th.Client, strings.NewReader(archive), logpoller.ArchiveFormatJSONL)
+		require.NoError(t, err)
+		assert.Equal(t, stats, imported)
+		logs, err := o.SelectLogsByBlockRange(ctx, 1, latest.BlockNumber)
+		require.NoError(t, err)
+		assert.Len(t, logs, 3)
+	})
+
+	t.Run("rejects blocks which don't match the stored ones", func(t *testing.T) {
+		o, _, err := importArchive(t, archive, func(o *logpoller.DSORM) {
+			require.NoError(t, o.InsertBlock(ctx, common.HexToHash("0xdead"), latest.BlockNumber, time.Now(), 0, 0))
+		})
+		require.ErrorIs(t, err, logpoller.ErrArchiveBlockMismatch)
+		assertEmpty(t, o)
+	})
+
+	t.Run("rejects blocks which aren't canonical", func(t *testing.T) {
+		forked := strings.ReplaceAll(archive, latest.BlockHash.Hex(), common.HexToHash("0xdead").Hex())
+		o, _, err := importArchive(t, forked, nil)
+		require.ErrorIs(t, err, logpoller.ErrArchiveBlockMismatch)
+		assertEmpty(t, o)
+	})
+
+	t.Run("rejects orphan logs", func(t *testing.T) {
+		var logsOnly []string
+		for _, line := range strings.Split(archive, "\n") {
+			if !strings.Contains(line, `"type":"block"`) {
+				logsOnly = append(logsOnly, line)
+			}
+		}
+		o, _, err := importArchive(t, strings.Join(logsOnly, "\n"), nil)
+		require.ErrorIs(t, err, logpoller.ErrArchiveOrphanLog)
+		assertEmpty(t, o)
+	})
+}
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evm.log_poller_finality_recoveries (
+    id BIGSERIAL PRIMARY KEY,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    action TEXT NOT NULL,
+    lca_block_number BIGINT NOT NULL,
+    lca_block_hash BYTEA NOT NULL,
+    latest_block_number BIGINT NOT NULL,
+    latest_block_hash BYTEA NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_log_poller_finality_recoveries_chain_created
+    ON evm.log_poller_finality_recoveries (evm_chain_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS evm.log_poller_finality_recoveries;
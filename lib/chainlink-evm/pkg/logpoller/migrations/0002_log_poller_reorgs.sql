@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evm.log_poller_reorgs (
+    id BIGSERIAL PRIMARY KEY,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    start_block_number BIGINT NOT NULL,
+    end_block_number BIGINT NOT NULL,
+    depth BIGINT NOT NULL,
+    old_block_hashes BYTEA[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_log_poller_reorgs_chain_blocks
+    ON evm.log_poller_reorgs (evm_chain_id, start_block_number, end_block_number);
+CREATE INDEX IF NOT EXISTS idx_log_poller_reorgs_chain_created
+    ON evm.log_poller_reorgs (evm_chain_id, created_at);
+
+CREATE TABLE IF NOT EXISTS evm.log_poller_reorged_logs (
+    reorg_id BIGINT NOT NULL REFERENCES evm.log_poller_reorgs (id) ON DELETE CASCADE,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    log_id BIGINT NOT NULL,
+    block_hash BYTEA NOT NULL,
+    block_number BIGINT NOT NULL,
+    log_index BIGINT NOT NULL,
+    address BYTEA NOT NULL,
+    event_sig BYTEA NOT NULL,
+    tx_hash BYTEA NOT NULL,
+    filter_names TEXT[] NOT NULL,
+    PRIMARY KEY (reorg_id, log_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS evm.log_poller_reorged_logs;
+DROP TABLE IF EXISTS evm.log_poller_reorgs;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE evm.logs
+    ADD COLUMN IF NOT EXISTS tx_from BYTEA,
+    ADD COLUMN IF NOT EXISTS tx_to BYTEA,
+    ADD COLUMN IF NOT EXISTS tx_status BIGINT,
+    ADD COLUMN IF NOT EXISTS tx_gas_used BIGINT;
+ALTER TABLE evm.log_poller_filters
+    ADD COLUMN IF NOT EXISTS enrich_tx BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE evm.log_poller_filters
+    DROP COLUMN IF EXISTS enrich_tx;
+ALTER TABLE evm.logs
+    DROP COLUMN IF EXISTS tx_from,
+    DROP COLUMN IF EXISTS tx_to,
+    DROP COLUMN IF EXISTS tx_status,
+    DROP COLUMN IF EXISTS tx_gas_used;
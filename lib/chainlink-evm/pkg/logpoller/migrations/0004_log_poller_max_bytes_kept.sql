@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE evm.log_poller_filters
+    ADD COLUMN IF NOT EXISTS max_bytes_kept NUMERIC(78,0) NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE evm.log_poller_filters
+    DROP COLUMN IF EXISTS max_bytes_kept;
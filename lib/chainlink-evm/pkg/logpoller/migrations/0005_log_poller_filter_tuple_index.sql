@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE evm.log_poller_filters
+    ADD COLUMN IF NOT EXISTS tuple_index INTEGER;
+
+-- +goose Down
+ALTER TABLE evm.log_poller_filters
+    DROP COLUMN IF EXISTS tuple_index;
@@ -0,0 +1,40 @@
+// Package migrations contains the goose migrations of the evm schema objects used by the log poller on top of the
+// base schema created by the node. The node applies them with Up, after its own migrations and before starting any
+// chain. Their versions are tracked in their own table, TableName, so they don't collide with the node's migrations.
+//
+// Every migration is idempotent, so it can be applied to databases which already contain some of its objects.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+)
+
+// TableName is the table in which the applied versions of these migrations are recorded.
+const TableName = "evm.log_poller_migrations"
+
+// FS holds the migrations, named <version>_<description>.sql.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// Up applies the migrations which haven't been applied to db yet.
+func Up(ctx context.Context, db *sql.DB) error {
+	store, err := database.NewStore(database.DialectPostgres, TableName)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations store: %w", err)
+	}
+	provider, err := goose.NewProvider("", db, FS, goose.WithStore(store), goose.WithDisableGlobalRegistry(true))
+	if err != nil {
+		return fmt.Errorf("failed to create migrations provider: %w", err)
+	}
+	if _, err = provider.Up(ctx); err != nil {
+		return fmt.Errorf("failed to apply log poller migrations: %w", err)
+	}
+	return nil
+}
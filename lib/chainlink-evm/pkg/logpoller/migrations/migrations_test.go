@@ -0,0 +1,52 @@
+package migrations_test
+
+import (
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller/migrations"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+func TestMigrations(t *testing.T) {
+	names, err := fs.Glob(migrations.FS, "*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+
+	nameRegex := regexp.MustCompile(`^(\d{4})_[a-z0-9_]+\.sql$`)
+	for i, name := range names {
+		matches := nameRegex.FindStringSubmatch(name)
+		require.NotNil(t, matches, "invalid migration name %s", name)
+		version, err := strconv.Atoi(matches[1])
+		require.NoError(t, err)
+		assert.Equal(t, i+1, version, "migrations must be numbered consecutively, got %s", name)
+
+		b, err := migrations.FS.ReadFile(name)
+		require.NoError(t, err)
+		up, down, ok := strings.Cut(string(b), "-- +goose Down")
+		require.True(t, ok, "%s has no down migration", name)
+		assert.True(t, strings.HasPrefix(up, "-- +goose Up\n"), "%s must start with its up migration", name)
+		assert.NotEmpty(t, strings.TrimSpace(down), "%s has an empty down migration", name)
+	}
+}
+
+func TestUp(t *testing.T) {
+	// NewSqlxDB already applied the migrations, applying them again is a no-op
+	db := testutils.NewSqlxDB(t)
+	require.NoError(t, migrations.Up(testutils.Context(t), db.DB))
+
+	var versions int
+	require.NoError(t, db.GetContext(testutils.Context(t), &versions, `SELECT COUNT(*) FROM `+migrations.TableName+` WHERE version_id > 0`))
+	names, err := fs.Glob(migrations.FS, "*.sql")
+	require.NoError(t, err)
+	assert.Equal(t, len(names), versions)
+
+	var recoveries int
+	require.NoError(t, db.GetContext(testutils.Context(t), &recoveries, `SELECT COUNT(*) FROM evm.log_poller_finality_recoveries`))
+}
@@ -23,6 +23,65 @@ type Block struct {
 	CreatedAt            time.Time
 }
 
+// FinalityRecoveryAction is a single step taken by LogPoller while automatically recovering from a finality violation.
+type FinalityRecoveryAction string
+
+const (
+	// FinalityRecoveryLCAFound is recorded once the deepest block still present on chain was found.
+	FinalityRecoveryLCAFound FinalityRecoveryAction = "lca_found"
+	// FinalityRecoveryRewound is recorded after all logs and blocks after the LCA were removed.
+	FinalityRecoveryRewound FinalityRecoveryAction = "rewound"
+	// FinalityRecoveryFailed is recorded when the recovery could not be completed and will be retried.
+	FinalityRecoveryFailed FinalityRecoveryAction = "failed"
+)
+
+// FinalityRecovery is an audit record of an automatic finality violation recovery step.
+type FinalityRecovery struct {
+	EVMChainID        *big.Big
+	Action            FinalityRecoveryAction
+	LCABlockNumber    int64
+	LCABlockHash      common.Hash
+	LatestBlockNumber int64
+	LatestBlockHash   common.Hash
+	Reason            string
+	CreatedAt         time.Time
+}
+
+// Reorg is an audit record of a reorg handled by LogPoller, kept for incident analysis.
+// All blocks in [StartBlockNumber, EndBlockNumber] were replaced, together with the logs they contained.
+type Reorg struct {
+	ID               int64
+	EVMChainID       *big.Big
+	StartBlockNumber int64 // first block replaced by the reorg, i.e. LCA+1
+	EndBlockNumber   int64 // last block LogPoller had saved before the reorg was detected
+	Depth            int64
+	OldBlockHashes   pq.ByteaArray // hashes of the replaced blocks, ordered by block number
+	RemovedLogs      []ReorgedLog  `db:"-"`
+	CreatedAt        time.Time
+}
+
+// GetOldBlockHashes returns the hashes of the blocks replaced by the reorg.
+func (r *Reorg) GetOldBlockHashes() []common.Hash {
+	hashes := make([]common.Hash, 0, len(r.OldBlockHashes))
+	for _, h := range r.OldBlockHashes {
+		hashes = append(hashes, common.BytesToHash(h))
+	}
+	return hashes
+}
+
+// ReorgedLog describes a log removed from the database while handling a Reorg.
+type ReorgedLog struct {
+	ReorgID     int64
+	LogID       int64
+	BlockHash   common.Hash
+	BlockNumber int64
+	LogIndex    int64
+	Address     common.Address
+	EventSig    common.Hash
+	TxHash      common.Hash
+	FilterNames pq.StringArray // names of the filters the log matched at the time of the reorg
+}
+
 // Log represents an EVM log.
 type Log struct {
 	EVMChainID     *big.Big
@@ -36,6 +95,31 @@ type Log struct {
 	TxHash         common.Hash
 	Data           []byte
 	CreatedAt      time.Time
+	// Transaction details, only set for logs matched by a filter with EnrichTx.
+	TxFrom    *common.Address
+	TxTo      *common.Address
+	TxStatus  *uint64
+	TxGasUsed *uint64
+}
+
+// FilterStats describes the logs stored for a single filter.
+type FilterStats struct {
+	Name           string
+	Logs           int64
+	OldestBlock    int64
+	NewestBlock    int64
+	EstimatedBytes int64 // on-disk size of the rows as estimated by pg_column_size, excluding indexes
+}
+
+// AggregateResult is a single group returned by an aggregate query. Only the fields of the requested
+// GroupBy kinds are set, and Value is only set for AggregateMinWord and AggregateMaxWord.
+type AggregateResult struct {
+	Address     common.Address
+	EventSig    common.Hash
+	Topic       common.Hash
+	BlockBucket int64
+	Count       int64
+	Value       *common.Hash
 }
 
 func (l *Log) GetTopics() []common.Hash {
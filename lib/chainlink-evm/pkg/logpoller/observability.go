@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
@@ -16,6 +17,50 @@ import (
 	"github.com/smartcontractkit/chainlink-framework/metrics"
 )
 
+var (
+	promLpFinalityRecoveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_finality_violation_recoveries",
+		Help: "Error level counter of steps taken by LogPoller while automatically recovering from a finality violation, by action.",
+	}, []string{"evmChainID", "action"})
+	promLpPrunedLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_pruned_logs",
+		Help: "Counter of logs removed by LogPoller pruning, by reason (expired, excess or unmatched).",
+	}, []string{"evmChainID", "reason"})
+	promLpFilterLogs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_poller_filter_logs",
+		Help: "Number of logs stored for a filter, as of the last log prune.",
+	}, []string{"evmChainID", "filterName"})
+	promLpFilterBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_poller_filter_estimated_bytes",
+		Help: "Estimated storage size of the logs stored for a filter, as of the last log prune.",
+	}, []string{"evmChainID", "filterName"})
+	promLpFilterOldestBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_poller_filter_oldest_block",
+		Help: "Oldest block number with a log stored for a filter, as of the last log prune.",
+	}, []string{"evmChainID", "filterName"})
+	promLpFilterNewestBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_poller_filter_newest_block",
+		Help: "Newest block number with a log stored for a filter, as of the last log prune.",
+	}, []string{"evmChainID", "filterName"})
+	promLpBloomSkippedBlocks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_bloom_skipped_blocks",
+		Help: "Counter of unfinalized blocks whose eth_getLogs call was skipped, because their logsBloom can't match any filter.",
+	}, []string{"evmChainID"})
+)
+
+// setFilterStatsMetrics replaces the per-filter gauges of chainID, so that unregistered filters are no longer reported.
+func setFilterStatsMetrics(chainID *big.Int, stats []FilterStats) {
+	for _, gauge := range []*prometheus.GaugeVec{promLpFilterLogs, promLpFilterBytes, promLpFilterOldestBlock, promLpFilterNewestBlock} {
+		gauge.DeletePartialMatch(prometheus.Labels{"evmChainID": chainID.String()})
+	}
+	for _, s := range stats {
+		promLpFilterLogs.WithLabelValues(chainID.String(), s.Name).Set(float64(s.Logs))
+		promLpFilterBytes.WithLabelValues(chainID.String(), s.Name).Set(float64(s.EstimatedBytes))
+		promLpFilterOldestBlock.WithLabelValues(chainID.String(), s.Name).Set(float64(s.OldestBlock))
+		promLpFilterNewestBlock.WithLabelValues(chainID.String(), s.Name).Set(float64(s.NewestBlock))
+	}
+}
+
 // ObservedORM is a decorator layer for ORM used by LogPoller, responsible for pushing Prometheus metrics reporting duration and size of result set for the queries.
 // It doesn't change internal logic, because all calls are delegated to the origin ORM
 type ObservedORM struct {
@@ -72,6 +117,12 @@ func (o *ObservedORM) InsertFilter(ctx context.Context, filter Filter) error {
 	})
 }
 
+func (o *ObservedORM) InsertFinalityRecovery(ctx context.Context, recovery FinalityRecovery) error {
+	return withObservedExec(ctx, o, "InsertFinalityRecovery", metrics.Create, func() error {
+		return o.ORM.InsertFinalityRecovery(ctx, recovery)
+	})
+}
+
 func (o *ObservedORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
 	return withObservedQuery(ctx, o, "LoadFilters", func() (map[string]Filter, error) {
 		return o.ORM.LoadFilters(ctx)
@@ -96,6 +147,30 @@ func (o *ObservedORM) DeleteLogsAndBlocksAfter(ctx context.Context, start int64)
 	})
 }
 
+func (o *ObservedORM) DeleteReorgedLogsAndBlocksAfter(ctx context.Context, start int64) error {
+	return withObservedExec(ctx, o, "DeleteReorgedLogsAndBlocksAfter", metrics.Del, func() error {
+		return o.ORM.DeleteReorgedLogsAndBlocksAfter(ctx, start)
+	})
+}
+
+func (o *ObservedORM) DeleteExpiredReorgs(ctx context.Context, retention time.Duration) (int64, error) {
+	return withObservedExecAndRowsAffected(ctx, o, "DeleteExpiredReorgs", metrics.Del, func() (int64, error) {
+		return o.ORM.DeleteExpiredReorgs(ctx, retention)
+	})
+}
+
+func (o *ObservedORM) SelectFilterStats(ctx context.Context) ([]FilterStats, error) {
+	return withObservedQueryAndResults(ctx, o, "SelectFilterStats", func() ([]FilterStats, error) {
+		return o.ORM.SelectFilterStats(ctx)
+	})
+}
+
+func (o *ObservedORM) SelectReorgs(ctx context.Context, start, end int64) ([]Reorg, error) {
+	return withObservedQueryAndResults(ctx, o, "SelectReorgs", func() ([]Reorg, error) {
+		return o.ORM.SelectReorgs(ctx, start, end)
+	})
+}
+
 func (o *ObservedORM) DeleteExpiredLogs(ctx context.Context, limit int64) (int64, error) {
 	return withObservedExecAndRowsAffected(ctx, o, "DeleteExpiredLogs", metrics.Del, func() (int64, error) {
 		return o.ORM.DeleteExpiredLogs(ctx, limit)
@@ -246,6 +321,18 @@ func (o *ObservedORM) FilteredLogs(ctx context.Context, filter []query.Expressio
 	})
 }
 
+func (o *ObservedORM) FilteredLogsPage(ctx context.Context, filter []query.Expression, after *LogPosition, pageSize uint64, queryName string) ([]Log, error) {
+	return withObservedQueryAndResults(ctx, o, queryName, func() ([]Log, error) {
+		return o.ORM.FilteredLogsPage(ctx, filter, after, pageSize, queryName)
+	})
+}
+
+func (o *ObservedORM) AggregatedLogs(ctx context.Context, filter []query.Expression, aggregate Aggregate, queryName string) ([]AggregateResult, error) {
+	return withObservedQueryAndResults(ctx, o, queryName, func() ([]AggregateResult, error) {
+		return o.ORM.AggregatedLogs(ctx, filter, aggregate, queryName)
+	})
+}
+
 func withObservedQueryAndResults[T any](ctx context.Context, o *ObservedORM, queryName string, query func() ([]T, error)) ([]T, error) {
 	results, err := withObservedQuery(ctx, o, queryName, query)
 	if err == nil {
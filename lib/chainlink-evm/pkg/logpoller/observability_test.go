@@ -152,6 +152,25 @@ func TestCountersAreProperlyPopulatedForWrites(t *testing.T) {
 	assert.Equal(t, 2, int(testutil.ToFloat64(orm.blocksInserted.WithLabelValues(network, "420"))))
 }
 
+func TestFilterStatsMetrics(t *testing.T) {
+	chainID := big.NewInt(4242)
+	t.Cleanup(func() { setFilterStatsMetrics(chainID, nil) })
+
+	setFilterStatsMetrics(chainID, []FilterStats{
+		{Name: "a", Logs: 10, OldestBlock: 5, NewestBlock: 20, EstimatedBytes: 2048},
+		{Name: "b", Logs: 1, OldestBlock: 7, NewestBlock: 7, EstimatedBytes: 100},
+	})
+	assert.Equal(t, 10, counterFromGaugeByLabels(promLpFilterLogs, "4242", "a"))
+	assert.Equal(t, 2048, counterFromGaugeByLabels(promLpFilterBytes, "4242", "a"))
+	assert.Equal(t, 5, counterFromGaugeByLabels(promLpFilterOldestBlock, "4242", "a"))
+	assert.Equal(t, 7, counterFromGaugeByLabels(promLpFilterNewestBlock, "4242", "b"))
+
+	// Filters missing from the next refresh are no longer reported
+	setFilterStatsMetrics(chainID, []FilterStats{{Name: "a", Logs: 3}})
+	assert.Equal(t, 3, counterFromGaugeByLabels(promLpFilterLogs, "4242", "a"))
+	assert.Equal(t, 1, testutil.CollectAndCount(promLpFilterLogs.MustCurryWith(prometheus.Labels{"evmChainID": "4242"})))
+}
+
 func generateRandomLogs(chainID, count int) []Log {
 	logs := make([]Log, count)
 	for i := range logs {
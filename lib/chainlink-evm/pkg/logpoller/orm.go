@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
 	pkgerrors "github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
@@ -27,6 +28,7 @@ type ORM interface {
 	InsertLogs(ctx context.Context, logs []Log) error
 	InsertLogsWithBlock(ctx context.Context, logs []Log, block Block) error
 	InsertFilter(ctx context.Context, filter Filter) error
+	InsertFinalityRecovery(ctx context.Context, recovery FinalityRecovery) error
 
 	LoadFilters(ctx context.Context) (map[string]Filter, error)
 	DeleteFilter(ctx context.Context, name string) error
@@ -35,6 +37,10 @@ type ORM interface {
 	InsertBlock(ctx context.Context, blockHash common.Hash, blockNumber int64, blockTimestamp time.Time, finalizedBlock int64, safeBlock int64) error
 	DeleteBlocksBefore(ctx context.Context, end int64, limit int64) (int64, error)
 	DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error
+	DeleteReorgedLogsAndBlocksAfter(ctx context.Context, start int64) error
+	DeleteExpiredReorgs(ctx context.Context, retention time.Duration) (int64, error)
+	SelectReorgs(ctx context.Context, start, end int64) ([]Reorg, error)
+	SelectFilterStats(ctx context.Context) ([]FilterStats, error)
 	SelectUnmatchedLogIDs(ctx context.Context, limit int64) (ids []uint64, err error)
 	DeleteExpiredLogs(ctx context.Context, limit int64) (int64, error)
 	SelectExcessLogIDs(ctx context.Context, limit int64) (rowIDs []uint64, err error)
@@ -67,6 +73,10 @@ type ORM interface {
 
 	// FilteredLogs accepts chainlink-common filtering DSL.
 	FilteredLogs(ctx context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, queryName string) ([]Log, error)
+	// FilteredLogsPage returns up to pageSize logs matching the chainlink-common filtering DSL positioned after the given log.
+	FilteredLogsPage(ctx context.Context, filter []query.Expression, after *LogPosition, pageSize uint64, queryName string) ([]Log, error)
+	// AggregatedLogs accepts chainlink-common filtering DSL and aggregates the matching logs.
+	AggregatedLogs(ctx context.Context, filter []query.Expression, aggregate Aggregate, queryName string) ([]AggregateResult, error)
 }
 
 type DSORM struct {
@@ -113,20 +123,69 @@ func (o *DSORM) InsertBlock(ctx context.Context, blockHash common.Hash, blockNum
 	return err
 }
 
+// InsertFinalityRecovery appends a finality violation recovery step to the audit table, see migrations/0001_log_poller_finality_recoveries.sql.
+func (o *DSORM) InsertFinalityRecovery(ctx context.Context, recovery FinalityRecovery) error {
+	args, err := newQueryArgs(o.chainID).
+		withField("action", recovery.Action).
+		withField("lca_block_number", recovery.LCABlockNumber).
+		withField("lca_block_hash", recovery.LCABlockHash).
+		withField("latest_block_number", recovery.LatestBlockNumber).
+		withField("latest_block_hash", recovery.LatestBlockHash).
+		withField("reason", recovery.Reason).
+		toArgs()
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO evm.log_poller_finality_recoveries
+				(evm_chain_id, action, lca_block_number, lca_block_hash, latest_block_number, latest_block_hash, reason, created_at)
+			VALUES (:evm_chain_id, :action, :lca_block_number, :lca_block_hash, :latest_block_number, :latest_block_hash, :reason, NOW())`
+	_, err = o.ds.NamedExecContext(ctx, query, args)
+	return err
+}
+
 // InsertFilter is idempotent.
 //
 // Each address/event pair must have a unique job id, so it may be removed when the job is deleted.
 // If a second job tries to overwrite the same pair, this should fail.
+// Explicit filter.Tuples are stored as separate rows tagged with their position in filter.Tuples, without crossing them
+// with filter.Addresses and filter.EventSigs.
 func (o *DSORM) InsertFilter(ctx context.Context, filter Filter) (err error) {
-	topicArrays := []evmtypes.HashArray{filter.Topic2, filter.Topic3, filter.Topic4}
+	if len(filter.Tuples) == 0 {
+		return o.insertFilterRows(ctx, filter, nil, filter.Addresses, filter.EventSigs, filter.Topic2, filter.Topic3, filter.Topic4)
+	}
+	return o.Transact(ctx, func(orm *DSORM) error {
+		if len(filter.Addresses) != 0 && len(filter.EventSigs) != 0 {
+			if err := orm.insertFilterRows(ctx, filter, nil, filter.Addresses, filter.EventSigs, filter.Topic2, filter.Topic3, filter.Topic4); err != nil {
+				return err
+			}
+		}
+		for i, tuple := range filter.Tuples {
+			err := orm.insertFilterRows(ctx, filter, &i, []common.Address{tuple.Address}, []common.Hash{tuple.EventSig}, tuple.Topic2, tuple.Topic3, tuple.Topic4)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertFilterRows inserts one row per element of the cross product addresses x eventSigs x topic2 x topic3 x topic4.
+// tupleIndex is the position of the tuple the rows belong to, or nil for rows of the filter's own cross product.
+// A row shared by the cross product and a tuple is kept as a cross product row, a row shared by several tuples is kept
+// by the first one: either way the rows reloaded by LoadFilters still match the same logs.
+func (o *DSORM) insertFilterRows(ctx context.Context, filter Filter, tupleIndex *int, addresses []common.Address, eventSigs []common.Hash, topic2, topic3, topic4 evmtypes.HashArray) error {
+	topicArrays := []evmtypes.HashArray{topic2, topic3, topic4}
 	args, err := newQueryArgs(o.chainID).
 		withField("name", filter.Name).
 		withRetention(filter.Retention).
 		withMaxLogsKept(filter.MaxLogsKept).
+		withField("max_bytes_kept", filter.MaxBytesKept).
 		withLogsPerBlock(filter.LogsPerBlock).
-		withAddressArray(filter.Addresses).
-		withEventSigArray(filter.EventSigs).
-		withTopicArrays(filter.Topic2, filter.Topic3, filter.Topic4).
+		withField("enrich_tx", filter.EnrichTx).
+		withField("tuple_index", tupleIndex).
+		withAddressArray(addresses).
+		withEventSigArray(eventSigs).
+		withTopicArrays(topic2, topic3, topic4).
 		toArgs()
 	if err != nil {
 		return err
@@ -143,14 +202,17 @@ func (o *DSORM) InsertFilter(ctx context.Context, filter Filter) (err error) {
 	// https://github.com/jmoiron/sqlx/issues/91, https://github.com/jmoiron/sqlx/issues/428
 	query := fmt.Sprintf(`
 		INSERT INTO evm.log_poller_filters
-	  		(name, evm_chain_id, retention, max_logs_kept, logs_per_block, created_at, address, event %s)
+	  		(name, evm_chain_id, retention, max_logs_kept, max_bytes_kept, logs_per_block, enrich_tx, tuple_index, created_at, address, event %s)
 		SELECT * FROM
-			(SELECT :name, :evm_chain_id ::::NUMERIC, :retention ::::BIGINT, :max_logs_kept ::::NUMERIC, :logs_per_block ::::NUMERIC, NOW()) x,
+			(SELECT :name, :evm_chain_id ::::NUMERIC, :retention ::::BIGINT, :max_logs_kept ::::NUMERIC, :max_bytes_kept ::::NUMERIC, :logs_per_block ::::NUMERIC, :enrich_tx ::::BOOLEAN, :tuple_index ::::INTEGER, NOW()) x,
 			(SELECT unnest(:address_array ::::BYTEA[]) addr) a,
 			(SELECT unnest(:event_sig_array ::::BYTEA[]) ev) e
 			%s
 		ON CONFLICT  (evm.f_log_poller_filter_hash(name, evm_chain_id, address, event, topic2, topic3, topic4))
-		DO UPDATE SET retention=:retention ::::BIGINT, max_logs_kept=:max_logs_kept ::::NUMERIC, logs_per_block=:logs_per_block ::::NUMERIC`,
+		DO UPDATE SET retention=:retention ::::BIGINT, max_logs_kept=:max_logs_kept ::::NUMERIC, max_bytes_kept=:max_bytes_kept ::::NUMERIC,
+			logs_per_block=:logs_per_block ::::NUMERIC, enrich_tx=:enrich_tx ::::BOOLEAN,
+			tuple_index=CASE WHEN evm.log_poller_filters.tuple_index IS NULL OR EXCLUDED.tuple_index IS NULL THEN NULL
+				ELSE LEAST(evm.log_poller_filters.tuple_index, EXCLUDED.tuple_index) END`,
 		topicsColumns.String(),
 		topicsSQL.String())
 
@@ -169,14 +231,16 @@ func (o *DSORM) DeleteFilter(ctx context.Context, name string) error {
 // LoadFilters returns all filters for this chain
 func (o *DSORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
 	query := `SELECT name,
-			ARRAY_AGG(DISTINCT address)::BYTEA[] AS addresses,
-			ARRAY_AGG(DISTINCT event)::BYTEA[] AS event_sigs,
-			ARRAY_AGG(DISTINCT topic2 ORDER BY topic2) FILTER(WHERE topic2 IS NOT NULL) AS topic2,
-			ARRAY_AGG(DISTINCT topic3 ORDER BY topic3) FILTER(WHERE topic3 IS NOT NULL) AS topic3,
-			ARRAY_AGG(DISTINCT topic4 ORDER BY topic4) FILTER(WHERE topic4 IS NOT NULL) AS topic4,
+			ARRAY_AGG(DISTINCT address) FILTER(WHERE tuple_index IS NULL)::BYTEA[] AS addresses,
+			ARRAY_AGG(DISTINCT event) FILTER(WHERE tuple_index IS NULL)::BYTEA[] AS event_sigs,
+			ARRAY_AGG(DISTINCT topic2 ORDER BY topic2) FILTER(WHERE topic2 IS NOT NULL AND tuple_index IS NULL) AS topic2,
+			ARRAY_AGG(DISTINCT topic3 ORDER BY topic3) FILTER(WHERE topic3 IS NOT NULL AND tuple_index IS NULL) AS topic3,
+			ARRAY_AGG(DISTINCT topic4 ORDER BY topic4) FILTER(WHERE topic4 IS NOT NULL AND tuple_index IS NULL) AS topic4,
 			MAX(logs_per_block) AS logs_per_block,
 			MAX(retention) AS retention,
-			MAX(max_logs_kept) AS max_logs_kept
+			MAX(max_logs_kept) AS max_logs_kept,
+			MAX(max_bytes_kept) AS max_bytes_kept,
+			BOOL_OR(enrich_tx) AS enrich_tx
 		FROM evm.log_poller_filters WHERE evm_chain_id = $1
 		GROUP BY name`
 	var rows []Filter
@@ -185,9 +249,69 @@ func (o *DSORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
 	for _, filter := range rows {
 		filters[filter.Name] = filter
 	}
+	if err != nil {
+		return filters, err
+	}
+
+	tuples, err := o.loadFilterTuples(ctx)
+	for name, nameTuples := range tuples {
+		filter, ok := filters[name]
+		if !ok {
+			continue
+		}
+		filter.Tuples = nameTuples
+		filters[name] = filter
+	}
 	return filters, err
 }
 
+// loadFilterTuples returns the explicit tuples of all filters, rebuilt from the rows tagged with their tuple_index.
+func (o *DSORM) loadFilterTuples(ctx context.Context) (map[string][]EventTuple, error) {
+	query := `SELECT name, tuple_index, address, event, topic2, topic3, topic4
+		FROM evm.log_poller_filters
+		WHERE evm_chain_id = $1 AND tuple_index IS NOT NULL
+		ORDER BY name, tuple_index, topic2, topic3, topic4`
+	var rows []struct {
+		Name       string
+		TupleIndex int
+		Address    common.Address
+		Event      common.Hash
+		Topic2     []byte
+		Topic3     []byte
+		Topic4     []byte
+	}
+	if err := o.ds.SelectContext(ctx, &rows, query, ubig.New(o.chainID)); err != nil {
+		return nil, err
+	}
+
+	tuples := make(map[string][]EventTuple)
+	for i, row := range rows {
+		nameTuples := tuples[row.Name]
+		if i == 0 || rows[i-1].Name != row.Name || rows[i-1].TupleIndex != row.TupleIndex {
+			nameTuples = append(nameTuples, EventTuple{Address: row.Address, EventSig: row.Event})
+		}
+		tuple := &nameTuples[len(nameTuples)-1]
+		tuple.Topic2 = appendDistinctTopic(tuple.Topic2, row.Topic2)
+		tuple.Topic3 = appendDistinctTopic(tuple.Topic3, row.Topic3)
+		tuple.Topic4 = appendDistinctTopic(tuple.Topic4, row.Topic4)
+		tuples[row.Name] = nameTuples
+	}
+	return tuples, nil
+}
+
+func appendDistinctTopic(topics evmtypes.HashArray, topic []byte) evmtypes.HashArray {
+	if topic == nil {
+		return topics
+	}
+	h := common.BytesToHash(topic)
+	for _, t := range topics {
+		if t == h {
+			return topics
+		}
+	}
+	return append(topics, h)
+}
+
 func blocksQuery(clause string) string {
 	return fmt.Sprintf(`SELECT %s FROM evm.log_poller_blocks %s`, strings.Join(blocksFields[:], ", "), clause)
 }
@@ -422,6 +546,126 @@ func (o *DSORM) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error
 	})
 }
 
+// DeleteReorgedLogsAndBlocksAfter behaves like DeleteLogsAndBlocksAfter, but first records the removed blocks and logs
+// in the reorg history, see migrations/0002_log_poller_reorgs.sql. The history is only kept for forensics, so failing to
+// record it is logged and the blocks and logs are removed regardless.
+func (o *DSORM) DeleteReorgedLogsAndBlocksAfter(ctx context.Context, start int64) error {
+	return o.Transact(ctx, func(orm *DSORM) error {
+		// A failed statement aborts the whole transaction, so the history is recorded under a savepoint which is rolled
+		// back on failure.
+		if _, err := orm.ds.ExecContext(ctx, `SAVEPOINT record_reorg`); err != nil {
+			return err
+		}
+		if err := orm.recordReorg(ctx, start); err != nil {
+			o.lggr.Errorw("Failed to record reorg history", "start", start, "err", err)
+			if _, err = orm.ds.ExecContext(ctx, `ROLLBACK TO SAVEPOINT record_reorg`); err != nil {
+				return err
+			}
+		}
+		return orm.DeleteLogsAndBlocksAfter(ctx, start)
+	})
+}
+
+// recordReorg copies the blocks and logs after start to the reorg history.
+func (o *DSORM) recordReorg(ctx context.Context, start int64) error {
+	var reorgID sql.NullInt64
+	err := o.ds.GetContext(ctx, &reorgID, `INSERT INTO evm.log_poller_reorgs
+			(evm_chain_id, start_block_number, end_block_number, depth, old_block_hashes, created_at)
+		SELECT evm_chain_id, $2, MAX(block_number), MAX(block_number) - $2 + 1, ARRAY_AGG(block_hash ORDER BY block_number), NOW()
+			FROM evm.log_poller_blocks
+			WHERE evm_chain_id = $1 AND block_number >= $2
+			GROUP BY evm_chain_id
+		RETURNING id`,
+		ubig.New(o.chainID), start)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to record reorg: %w", err)
+	}
+	_, err = o.ds.ExecContext(ctx, `INSERT INTO evm.log_poller_reorged_logs
+			(reorg_id, evm_chain_id, log_id, block_hash, block_number, log_index, address, event_sig, tx_hash, filter_names)
+		SELECT $3, l.evm_chain_id, l.id, l.block_hash, l.block_number, l.log_index, l.address, l.event_sig, l.tx_hash,
+			COALESCE(ARRAY_AGG(DISTINCT f.name) FILTER (WHERE f.name IS NOT NULL), '{}')
+		FROM evm.logs l LEFT JOIN evm.log_poller_filters f
+			ON f.evm_chain_id = l.evm_chain_id AND f.address = l.address AND f.event = l.event_sig
+		WHERE l.evm_chain_id = $1 AND l.block_number >= $2
+		GROUP BY l.evm_chain_id, l.id, l.block_hash, l.block_number, l.log_index, l.address, l.event_sig, l.tx_hash`,
+		ubig.New(o.chainID), start, reorgID.Int64)
+	if err != nil {
+		return fmt.Errorf("failed to record reorged logs: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredReorgs removes reorg history older than retention. Retention of 0 keeps the history forever.
+func (o *DSORM) DeleteExpiredReorgs(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention == 0 {
+		return 0, nil
+	}
+	result, err := o.ds.ExecContext(ctx, `DELETE FROM evm.log_poller_reorgs
+		WHERE evm_chain_id = $1 AND created_at <= STATEMENT_TIMESTAMP() - ($2 / 10^9 * interval '1 second')`,
+		ubig.New(o.chainID), retention)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SelectFilterStats returns the number, block range and estimated size of the logs stored for each registered filter.
+func (o *DSORM) SelectFilterStats(ctx context.Context) ([]FilterStats, error) {
+	query := `
+		WITH filters AS (
+			SELECT DISTINCT name, address, event FROM evm.log_poller_filters WHERE evm_chain_id = $1
+		)
+		SELECT f.name,
+				COUNT(l.id) AS logs,
+				COALESCE(MIN(l.block_number), 0) AS oldest_block,
+				COALESCE(MAX(l.block_number), 0) AS newest_block,
+				COALESCE(SUM(pg_column_size(l.*)), 0) AS estimated_bytes
+			FROM filters f LEFT JOIN evm.logs l ON
+				l.evm_chain_id = $1 AND l.address = f.address AND l.event_sig = f.event
+			GROUP BY f.name
+			ORDER BY f.name`
+
+	var stats []FilterStats
+	err := o.ds.SelectContext(ctx, &stats, query, ubig.New(o.chainID))
+	return stats, err
+}
+
+// SelectReorgs returns the reorgs that replaced at least one block in the range [start, end], together with the logs they removed.
+func (o *DSORM) SelectReorgs(ctx context.Context, start, end int64) ([]Reorg, error) {
+	var reorgs []Reorg
+	err := o.ds.SelectContext(ctx, &reorgs, `SELECT id, evm_chain_id, start_block_number, end_block_number, depth, old_block_hashes, created_at
+		FROM evm.log_poller_reorgs
+		WHERE evm_chain_id = $1 AND start_block_number <= $3 AND end_block_number >= $2
+		ORDER BY created_at, id`,
+		ubig.New(o.chainID), start, end)
+	if err != nil || len(reorgs) == 0 {
+		return reorgs, err
+	}
+
+	ids := make([]int64, 0, len(reorgs))
+	byID := make(map[int64]int, len(reorgs))
+	for i, r := range reorgs {
+		ids = append(ids, r.ID)
+		byID[r.ID] = i
+	}
+	var logs []ReorgedLog
+	err = o.ds.SelectContext(ctx, &logs, `SELECT reorg_id, log_id, block_hash, block_number, log_index, address, event_sig, tx_hash, filter_names
+		FROM evm.log_poller_reorged_logs
+		WHERE reorg_id = ANY($1)
+		ORDER BY reorg_id, block_number, log_index`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range logs {
+		i := byID[l.ReorgID]
+		reorgs[i].RemovedLogs = append(reorgs[i].RemovedLogs, l)
+	}
+	return reorgs, nil
+}
+
 type Exp struct {
 	Address      common.Address
 	EventSig     common.Hash
@@ -431,16 +675,22 @@ type Exp struct {
 }
 
 func (o *DSORM) SelectUnmatchedLogIDs(ctx context.Context, limit int64) (ids []uint64, err error) {
-	batchLogsSubQuery := `SELECT id, evm_chain_id, address, event_sig FROM evm.logs
+	batchLogsSubQuery := `SELECT id, evm_chain_id, address, event_sig, topics FROM evm.logs
                                 WHERE evm_chain_id = $1 AND block_number >= $2 AND block_number <= $3`
 
+	// Topics only narrow down the logs matched by rows of explicit tuples.
 	query := fmt.Sprintf(`
 		SELECT l.id FROM (%s) l LEFT JOIN (
-			SELECT evm_chain_id, address, event
+			SELECT DISTINCT evm_chain_id, address, event,
+				CASE WHEN tuple_index IS NOT NULL THEN topic2 END AS topic2,
+				CASE WHEN tuple_index IS NOT NULL THEN topic3 END AS topic3,
+				CASE WHEN tuple_index IS NOT NULL THEN topic4 END AS topic4
 			FROM evm.log_poller_filters
 				WHERE evm_chain_id = $1
-				GROUP BY evm_chain_id, address, event
-		) r ON l.evm_chain_id = r.evm_chain_id AND l.address = r.address AND l.event_sig = r.event
+		) r ON l.evm_chain_id = r.evm_chain_id AND l.address = r.address AND l.event_sig = r.event AND
+			(r.topic2 IS NULL OR r.topic2 = l.topics[2]) AND
+			(r.topic3 IS NULL OR r.topic3 = l.topics[3]) AND
+			(r.topic4 IS NULL OR r.topic4 = l.topics[4])
 		WHERE l.evm_chain_id = $1 AND r.evm_chain_id IS NULL
 	`, batchLogsSubQuery)
 
@@ -464,28 +714,41 @@ func (o *DSORM) SelectUnmatchedLogIDs(ctx context.Context, limit int64) (ids []u
 	return r.AllResults(), err
 }
 
-// SelectExcessLogIDs finds any logs old enough that MaxLogsKept has been exceeded for every filter they match.
+// SelectExcessLogIDs finds any logs old enough that MaxLogsKept or MaxBytesKept has been exceeded for every filter they match.
 func (o *DSORM) SelectExcessLogIDs(ctx context.Context, limit int64) (results []uint64, err error) {
-	// Roll up the filter table into 1 row per filter
+	byteQuota, err := o.hasByteQuota(ctx)
+	if err != nil {
+		return results, err
+	}
+
+	// Roll up the filter table into 1 row per filter and address/event pair
 	withSubQuery := `
-		SELECT name,
-				ARRAY_AGG(address) AS addresses, ARRAY_AGG(event) AS events,
-				MAX(max_logs_kept) AS max_logs_kept -- Should all be the same, just need MAX for GROUP BY
+		SELECT name, address, event,
+				MAX(max_logs_kept) AS max_logs_kept%s -- Should all be the same, just need MAX for GROUP BY
 			FROM evm.log_poller_filters WHERE evm_chain_id=$1
-			GROUP BY name`
+			GROUP BY name, address, event`
 
-	// Count logs matching each filter in reverse order, labeling anything after the filter.max_logs_kept'th with old=true
+	// Count logs matching each filter in reverse order, labeling anything after the filter.max_logs_kept'th with old=true.
+	// Likewise, sum up the estimated size of the newest logs, labeling anything past filter.max_bytes_kept with old=true.
+	// Sizing every log is expensive, so that's only done while a filter has a byte quota.
 	countLogsSubQuery := `
-		SELECT l.id, block_number, log_index, max_logs_kept != 0 AND
-				ROW_NUMBER() OVER(PARTITION BY f.name ORDER BY block_number, log_index DESC) > max_logs_kept AS old
+		SELECT l.id, block_number, log_index, (max_logs_kept != 0 AND
+				ROW_NUMBER() OVER(PARTITION BY f.name ORDER BY block_number, log_index DESC) > max_logs_kept)%s AS old
 			FROM filters f JOIN evm.logs l ON
-				l.address = ANY(f.addresses) AND l.event_sig = ANY(f.events)
+				l.address = f.address AND l.event_sig = f.event
 			WHERE evm_chain_id = $1 AND block_number >= $2 AND block_number <= $3
 	`
+	var maxBytesKept, byteQuotaExceeded string
+	if byteQuota {
+		maxBytesKept = `,
+				MAX(max_bytes_kept) AS max_bytes_kept`
+		byteQuotaExceeded = ` OR (max_bytes_kept != 0 AND
+				SUM(pg_column_size(l.*)) OVER(PARTITION BY f.name ORDER BY block_number DESC, log_index DESC) > max_bytes_kept)`
+	}
 
 	// Return all logs considered "old" by every filter they match
 	query := fmt.Sprintf(`WITH filters AS ( %s ) SELECT id FROM ( %s ) x GROUP BY id, block_number, log_index HAVING BOOL_AND(old)`,
-		withSubQuery, countLogsSubQuery)
+		fmt.Sprintf(withSubQuery, maxBytesKept), fmt.Sprintf(countLogsSubQuery, byteQuotaExceeded))
 
 	latestBlock, err := o.SelectLatestBlock(ctx)
 	if err != nil {
@@ -506,6 +769,14 @@ func (o *DSORM) SelectExcessLogIDs(ctx context.Context, limit int64) (results []
 	return r.AllResults(), err
 }
 
+// hasByteQuota reports whether any filter of this chain limits the size of its logs with MaxBytesKept.
+func (o *DSORM) hasByteQuota(ctx context.Context) (bool, error) {
+	var byteQuota bool
+	err := o.ds.GetContext(ctx, &byteQuota, `SELECT EXISTS (
+		SELECT 1 FROM evm.log_poller_filters WHERE evm_chain_id = $1 AND max_bytes_kept != 0)`, ubig.New(o.chainID))
+	return byteQuota, err
+}
+
 // DeleteExpiredLogs removes any logs which either:
 //   - don't match any currently registered filters, or
 //   - have a timestamp older than any matching filter's retention, UNLESS there is at
@@ -566,6 +837,14 @@ func (o *DSORM) InsertLogsWithBlock(ctx context.Context, logs []Log, block Block
 }
 
 func (o *DSORM) insertLogsWithinTx(ctx context.Context, logs []Log, tx sqlutil.DataSource) error {
+	query := `INSERT INTO evm.logs
+					(evm_chain_id, log_index, block_hash, block_number, block_timestamp, address, event_sig, topics, tx_hash, data,
+					 tx_from, tx_to, tx_status, tx_gas_used, created_at)
+				VALUES
+					(:evm_chain_id, :log_index, :block_hash, :block_number, :block_timestamp, :address, :event_sig, :topics, :tx_hash, :data,
+					 :tx_from, :tx_to, :tx_status, :tx_gas_used, NOW())
+				ON CONFLICT DO NOTHING`
+
 	batchInsertSize := 4000
 	for i := 0; i < len(logs); i += batchInsertSize {
 		start, end := i, i+batchInsertSize
@@ -573,12 +852,6 @@ func (o *DSORM) insertLogsWithinTx(ctx context.Context, logs []Log, tx sqlutil.D
 			end = len(logs)
 		}
 
-		query := `INSERT INTO evm.logs
-					(evm_chain_id, log_index, block_hash, block_number, block_timestamp, address, event_sig, topics, tx_hash, data, created_at)
-				VALUES
-					(:evm_chain_id, :log_index, :block_hash, :block_number, :block_timestamp, :address, :event_sig, :topics, :tx_hash, :data, NOW())
-				ON CONFLICT DO NOTHING`
-
 		_, err := tx.NamedExecContext(ctx, query, logs[start:end])
 		if err != nil {
 			if pkgerrors.Is(err, context.DeadlineExceeded) && batchInsertSize > 500 {
@@ -1156,6 +1429,54 @@ func (o *DSORM) FilteredLogs(ctx context.Context, filter []query.Expression, lim
 	return logs, nil
 }
 
+func (o *DSORM) FilteredLogsPage(ctx context.Context, filter []query.Expression, after *LogPosition, pageSize uint64, _ string) ([]Log, error) {
+	qs, args, err := (&pgDSLParser{}).buildKeysetQuery(o.chainID, filter, after, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := args.toArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	query, sqlArgs, err := o.ds.BindNamed(qs, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []Log
+	if err = o.ds.SelectContext(ctx, &logs, query, sqlArgs...); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (o *DSORM) AggregatedLogs(ctx context.Context, filter []query.Expression, aggregate Aggregate, _ string) ([]AggregateResult, error) {
+	qs, args, err := (&pgDSLParser{}).buildAggregateQuery(o.chainID, filter, aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := args.toArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	query, sqlArgs, err := o.ds.BindNamed(qs, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AggregateResult
+	if err = o.ds.SelectContext(ctx, &results, query, sqlArgs...); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 // DeleteLogsByRowID accepts a list of log row id's to delete
 func (o *DSORM) DeleteLogsByRowID(ctx context.Context, rowIDs []uint64) (int64, error) {
 	result, err := o.ds.ExecContext(ctx, `DELETE FROM evm.logs WHERE id = ANY($1)`, rowIDs)
@@ -708,6 +708,7 @@ func TestLogPollerFilters(t *testing.T) {
 	event1 := EmitterABI.Events["Log1"].ID
 	event2 := EmitterABI.Events["Log2"].ID
 	address := common.HexToAddress("0x1234")
+	address2 := common.HexToAddress("0x5678")
 	topicA := common.HexToHash("0x1111")
 	topicB := common.HexToHash("0x2222")
 	topicC := common.HexToHash("0x3333")
@@ -747,6 +748,20 @@ func TestLogPollerFilters(t *testing.T) {
 		Addresses: types.AddressArray{address},
 		EventSigs: types.HashArray{event1},
 		Topic3:    types.HashArray{topicC},
+	}, {
+		Name: "per-address events",
+		Tuples: []logpoller.EventTuple{
+			{Address: address, EventSig: event1, Topic2: types.HashArray{topicA, topicB}},
+			{Address: address2, EventSig: event2},
+			{Address: address, EventSig: event1, Topic3: types.HashArray{topicC}},
+		},
+	}, {
+		Name:      "cross product and per-address events",
+		Addresses: types.AddressArray{address},
+		EventSigs: types.HashArray{event1, event2},
+		Tuples: []logpoller.EventTuple{
+			{Address: address2, EventSig: event1, Topic2: types.HashArray{topicA}, Topic4: types.HashArray{topicC, topicD}},
+		},
 	}}
 
 	for _, filter := range filters {
@@ -766,6 +781,9 @@ func TestLogPollerFilters(t *testing.T) {
 			if len(filter.Topic4) > 0 {
 				expectedCount *= len(filter.Topic4)
 			}
+			for _, tuple := range filter.Tuples {
+				expectedCount += max(len(tuple.Topic2), 1) * max(len(tuple.Topic3), 1) * max(len(tuple.Topic4), 1)
+			}
 			assert.Equal(t, expectedCount, count)
 		})
 	}
@@ -782,6 +800,57 @@ func TestLogPollerFilters(t *testing.T) {
 	})
 }
 
+func TestORM_SelectUnmatchedLogIDs_Tuples(t *testing.T) {
+	t.Parallel()
+	th := SetupTH(t, lpOpts)
+	o := th.ORM
+	ctx := testutils.Context(t)
+
+	address, address2 := common.HexToAddress("0x1234"), common.HexToAddress("0x1235")
+	event := common.HexToHash("0x1599")
+	topicA, topicB := common.HexToHash("0x1600"), common.HexToHash("0x1601")
+
+	require.NoError(t, o.InsertBlock(ctx, common.HexToHash("0x1"), 1, time.Now(), 1, 1))
+	genLog := func(logIndex int64, addr common.Address, topics ...common.Hash) logpoller.Log {
+		l := GenLog(th.ChainID, logIndex, 1, "0x1", event[:], addr)
+		l.Topics = [][]byte{event[:]}
+		for _, topic := range topics {
+			l.Topics = append(l.Topics, topic.Bytes())
+		}
+		return l
+	}
+	require.NoError(t, o.InsertLogs(ctx, []logpoller.Log{
+		genLog(0, address, topicA, topicB),
+		genLog(1, address, topicB, topicA),
+		genLog(2, address, topicA),
+		genLog(3, address2, topicB),
+	}))
+
+	require.NoError(t, o.InsertFilter(ctx, logpoller.Filter{
+		Name:   "tuples",
+		Tuples: []logpoller.EventTuple{{Address: address, EventSig: event, Topic3: types.HashArray{topicB}}},
+	}))
+	// Topics of the cross product don't narrow down the logs it matches.
+	require.NoError(t, o.InsertFilter(ctx, logpoller.Filter{
+		Name:      "cross product",
+		Addresses: types.AddressArray{address2},
+		EventSigs: types.HashArray{event},
+		Topic2:    types.HashArray{topicA},
+	}))
+
+	ids, err := o.SelectUnmatchedLogIDs(ctx, 0)
+	require.NoError(t, err)
+	deleted, err := o.DeleteLogsByRowID(ctx, ids)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	logs, err := o.SelectLogsByBlockRange(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, int64(0), logs[0].LogIndex)
+	assert.Equal(t, int64(3), logs[1].LogIndex)
+}
+
 func insertLogsTopicValueRange(t *testing.T, chainID *big.Int, o logpoller.ORM, addr common.Address, blockNumber int, eventSig common.Hash, start, stop int) {
 	var lgs []logpoller.Log
 	for i := start; i <= stop; i++ {
@@ -2463,3 +2532,192 @@ func TestSelectLatestFinalizedBlock(t *testing.T) {
 		require.Equal(t, common.HexToHash("0x1231"), result.BlockHash)
 	})
 }
+
+func TestORM_ReorgHistory(t *testing.T) {
+	th := SetupTH(t, lpOpts)
+	o1 := th.ORM
+	ctx := testutils.Context(t)
+	event := EmitterABI.Events["Log1"].ID
+	address := common.HexToAddress("0x1234")
+
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{Name: "reorg filter", Addresses: []common.Address{address}, EventSigs: []common.Hash{event}}))
+	for i := int64(1); i <= 5; i++ {
+		require.NoError(t, o1.InsertBlock(ctx, common.BigToHash(big.NewInt(i)), i, time.Now(), 0, 0))
+	}
+	require.NoError(t, o1.InsertLogs(ctx, []logpoller.Log{
+		GenLog(th.ChainID, 1, 3, "0x3", event[:], address),
+		GenLog(th.ChainID, 1, 4, "0x4", event[:], address),
+		GenLog(th.ChainID, 2, 5, "0x5", event[:], common.HexToAddress("0x5678")),
+	}))
+
+	require.NoError(t, o1.DeleteReorgedLogsAndBlocksAfter(ctx, 4))
+
+	latest, err := o1.SelectLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), latest.BlockNumber)
+
+	reorgs, err := o1.SelectReorgs(ctx, 5, 10)
+	require.NoError(t, err)
+	require.Len(t, reorgs, 1)
+	reorg := reorgs[0]
+	assert.Equal(t, int64(4), reorg.StartBlockNumber)
+	assert.Equal(t, int64(5), reorg.EndBlockNumber)
+	assert.Equal(t, int64(2), reorg.Depth)
+	assert.Equal(t, []common.Hash{common.BigToHash(big.NewInt(4)), common.BigToHash(big.NewInt(5))}, reorg.GetOldBlockHashes())
+	require.Len(t, reorg.RemovedLogs, 2)
+	assert.Equal(t, int64(4), reorg.RemovedLogs[0].BlockNumber)
+	assert.Equal(t, []string{"reorg filter"}, []string(reorg.RemovedLogs[0].FilterNames))
+	assert.Equal(t, int64(5), reorg.RemovedLogs[1].BlockNumber)
+	assert.Empty(t, reorg.RemovedLogs[1].FilterNames)
+
+	reorgs, err = o1.SelectReorgs(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.Empty(t, reorgs)
+
+	removed, err := o1.DeleteExpiredReorgs(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), removed)
+	removed, err = o1.DeleteExpiredReorgs(ctx, time.Nanosecond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+}
+
+func TestORM_DeleteReorgedLogsAndBlocksAfter_WithoutHistory(t *testing.T) {
+	chainID := testutils.NewRandomEVMChainID()
+	db := testutils.NewSqlxDB(t)
+	o := logpoller.NewORM(chainID, db, logger.Test(t))
+	ctx := testutils.Context(t)
+	event := EmitterABI.Events["Log1"].ID
+
+	// failing to record the history doesn't prevent the reorg from being handled
+	testutils.MustExec(t, db, `DROP TABLE evm.log_poller_reorged_logs`)
+	for i := int64(1); i <= 5; i++ {
+		require.NoError(t, o.InsertBlock(ctx, common.BigToHash(big.NewInt(i)), i, time.Now(), 0, 0))
+	}
+	require.NoError(t, o.InsertLogs(ctx, []logpoller.Log{GenLog(chainID, 1, 4, "0x4", event[:], common.HexToAddress("0x1234"))}))
+
+	require.NoError(t, o.DeleteReorgedLogsAndBlocksAfter(ctx, 4))
+
+	latest, err := o.SelectLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), latest.BlockNumber)
+	logs, err := o.SelectLogsByBlockRange(ctx, 1, 5)
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+	// the partially recorded reorg was rolled back
+	reorgs, err := o.SelectReorgs(ctx, 1, 5)
+	require.NoError(t, err)
+	assert.Empty(t, reorgs)
+}
+
+func TestORM_AggregatedLogs(t *testing.T) {
+	th := SetupTH(t, lpOpts)
+	o1 := th.ORM
+	ctx := testutils.Context(t)
+	eventSig := common.HexToHash("0x1599")
+	addr1, addr2 := common.HexToAddress("0x1234"), common.HexToAddress("0x5678")
+	require.NoError(t, o1.InsertBlock(ctx, common.HexToHash("0x3"), 3, time.Now(), 2, 2))
+
+	var logs []logpoller.Log
+	for i, word := range []uint64{5, 300, 7} {
+		addr := addr1
+		if i == 2 {
+			addr = addr2
+		}
+		logs = append(logs, logpoller.Log{
+			EVMChainID:  ubig.New(th.ChainID),
+			LogIndex:    int64(i),
+			BlockHash:   common.BigToHash(big.NewInt(int64(i + 1))),
+			BlockNumber: int64(i + 1),
+			EventSig:    eventSig,
+			Topics:      [][]byte{eventSig[:]},
+			Address:     addr,
+			TxHash:      common.HexToHash("0x1888"),
+			Data:        logpoller.EvmWord(word).Bytes(),
+		})
+	}
+	require.NoError(t, o1.InsertLogs(ctx, logs))
+
+	results, err := o1.AggregatedLogs(ctx, []query.Expression{logpoller.NewEventSigFilter(eventSig)}, logpoller.Aggregate{
+		Func:    logpoller.AggregateMaxWord,
+		GroupBy: []logpoller.GroupBy{{Kind: logpoller.GroupByAddress}},
+	}, "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, addr1, results[0].Address)
+	assert.Equal(t, int64(2), results[0].Count)
+	assert.Equal(t, logpoller.EvmWord(300), *results[0].Value)
+	assert.Equal(t, addr2, results[1].Address)
+	assert.Equal(t, int64(1), results[1].Count)
+
+	// Only logs up to the finalized block 2 are counted.
+	results, err = o1.AggregatedLogs(ctx, []query.Expression{query.Confidence(primitives.Finalized)}, logpoller.Aggregate{
+		Func:    logpoller.AggregateCount,
+		GroupBy: []logpoller.GroupBy{{Kind: logpoller.GroupByBlockBucket, BucketSize: 2}},
+	}, "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(0), results[0].BlockBucket)
+	assert.Equal(t, int64(1), results[0].Count)
+	assert.Equal(t, int64(2), results[1].BlockBucket)
+	assert.Equal(t, int64(1), results[1].Count)
+}
+
+func TestORM_FilterStatsAndByteQuota(t *testing.T) {
+	th := SetupTH(t, lpOpts)
+	o1 := th.ORM
+	ctx := testutils.Context(t)
+	topic := common.HexToHash("0x1599")
+	addr := common.HexToAddress("0x1234")
+
+	require.NoError(t, o1.InsertBlock(ctx, common.HexToHash("0x1234"), 20, time.Now(), 20, 20))
+	var logs []logpoller.Log
+	for i := int64(0); i < 10; i++ {
+		logs = append(logs, logpoller.Log{
+			EVMChainID:     ubig.New(th.ChainID),
+			LogIndex:       i,
+			BlockHash:      common.BigToHash(big.NewInt(10 + i)),
+			BlockNumber:    10 + i,
+			EventSig:       topic,
+			Topics:         [][]byte{topic[:]},
+			Address:        addr,
+			TxHash:         common.HexToHash("0x1888"),
+			Data:           make([]byte, 256),
+			BlockTimestamp: time.Now(),
+		})
+	}
+	require.NoError(t, o1.InsertLogs(ctx, logs))
+
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{Name: "unlimited", Addresses: []common.Address{addr}, EventSigs: []common.Hash{topic}}))
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{Name: "empty", Addresses: []common.Address{common.HexToAddress("0x5")}, EventSigs: []common.Hash{topic}}))
+
+	stats, err := o1.SelectFilterStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, logpoller.FilterStats{Name: "empty"}, stats[0])
+	assert.Equal(t, "unlimited", stats[1].Name)
+	assert.Equal(t, int64(10), stats[1].Logs)
+	assert.Equal(t, int64(10), stats[1].OldestBlock)
+	assert.Equal(t, int64(19), stats[1].NewestBlock)
+	assert.Greater(t, stats[1].EstimatedBytes, int64(10*256))
+
+	// Nothing is excess while any matching filter keeps logs without limits
+	ids, err := o1.SelectExcessLogIDs(ctx, 0)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	// Keeping roughly 3 logs worth of bytes prunes the older ones
+	bytesPerLog := stats[1].EstimatedBytes / stats[1].Logs
+	require.NoError(t, o1.DeleteFilter(ctx, "unlimited"))
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{Name: "quota", Addresses: []common.Address{addr}, EventSigs: []common.Hash{topic}, MaxBytesKept: uint64(3*bytesPerLog + bytesPerLog/2)}))
+	ids, err = o1.SelectExcessLogIDs(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, ids, 7)
+	_, err = o1.DeleteLogsByRowID(ctx, ids)
+	require.NoError(t, err)
+
+	remaining, err := o1.SelectLogsByBlockRange(ctx, 0, 20)
+	require.NoError(t, err)
+	require.Len(t, remaining, 3)
+	assert.Equal(t, int64(17), remaining[0].BlockNumber)
+}
@@ -23,13 +23,18 @@ const (
 	timestampFieldName = "block_timestamp"
 	txHashFieldName    = "tx_hash"
 	eventSigFieldName  = "event_sig"
+	txFromFieldName    = "tx_from"
+	txToFieldName      = "tx_to"
+	txStatusFieldName  = "tx_status"
+	txGasUsedFieldName = "tx_gas_used"
 	defaultSort        = "block_number DESC, log_index DESC"
 )
 
 var (
 	ErrUnexpectedCursorFormat = errors.New("unexpected cursor format")
 	logsFields                = [...]string{"evm_chain_id", "log_index", "block_hash", "block_number",
-		"address", "event_sig", "topics", "tx_hash", "data", "created_at", "block_timestamp"}
+		"address", "event_sig", "topics", "tx_hash", "data", "created_at", "block_timestamp",
+		"tx_from", "tx_to", "tx_status", "tx_gas_used"}
 	blocksFields = [...]string{"evm_chain_id", "block_hash", "block_number", "block_timestamp",
 		"finalized_block_number", "created_at", "safe_block_number"}
 )
@@ -124,6 +129,46 @@ func (v *pgDSLParser) visitEventSigFilter(p *eventSigFilter) {
 	)
 }
 
+func (v *pgDSLParser) visitTxFromFilter(p *txFromFilter) {
+	v.expression = fmt.Sprintf(
+		"%s = :%s",
+		txFromFieldName,
+		v.args.withIndexedField(txFromFieldName, p.from),
+	)
+}
+
+func (v *pgDSLParser) visitTxToFilter(p *txToFilter) {
+	v.expression = fmt.Sprintf(
+		"%s = :%s",
+		txToFieldName,
+		v.args.withIndexedField(txToFieldName, p.to),
+	)
+}
+
+func (v *pgDSLParser) visitTxStatusFilter(p *txStatusFilter) {
+	v.expression = fmt.Sprintf(
+		"%s = :%s",
+		txStatusFieldName,
+		v.args.withIndexedField(txStatusFieldName, p.status),
+	)
+}
+
+func (v *pgDSLParser) visitTxGasUsedFilter(p *txGasUsedFilter) {
+	cmp, err := cmpOpToString(p.operator)
+	if err != nil {
+		v.err = err
+
+		return
+	}
+
+	v.expression = fmt.Sprintf(
+		"%s %s :%s",
+		txGasUsedFieldName,
+		cmp,
+		v.args.withIndexedField(txGasUsedFieldName, p.gasUsed),
+	)
+}
+
 func (v *pgDSLParser) nestedConfQuery(confidenceLevel primitives.ConfidenceLevel, confs uint64) string {
 	var (
 		from     = "FROM evm.log_poller_blocks "
@@ -268,6 +313,139 @@ func (v *pgDSLParser) buildQuery(chainID *big.Int, expressions []query.Expressio
 	return strings.Join(clauses, " "), v.args, nil
 }
 
+// buildKeysetQuery builds a query returning the first pageSize logs matching expressions which come after the given
+// position, in ascending (block_number, log_index) order. Unlike cursor limits, it's not restricted to finalized logs.
+func (v *pgDSLParser) buildKeysetQuery(chainID *big.Int, expressions []query.Expression, after *LogPosition, pageSize uint64) (string, *queryArgs, error) {
+	// reset transient properties
+	v.args = newQueryArgs(chainID)
+	v.expression = ""
+	v.err = nil
+
+	where, err := v.whereClause(expressions, query.LimitAndSort{})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if after != nil {
+		where = fmt.Sprintf("%s AND (block_number, log_index) > (:after_block_number, :after_log_index)", where)
+
+		v.args.withField("after_block_number", after.BlockNumber).
+			withField("after_log_index", after.LogIndex)
+	}
+
+	return strings.Join([]string{
+		logsQuery(""),
+		where,
+		"ORDER BY block_number ASC, log_index ASC",
+		fmt.Sprintf("LIMIT %d", pageSize),
+	}, " "), v.args, nil
+}
+
+// buildAggregateQuery builds a query returning one row per group defined by agg.GroupBy, with the matched log count and,
+// for AggregateMinWord and AggregateMaxWord, the min or max of the data word compared as uint256. Expressions are applied
+// exactly as in buildQuery, including confidence levels.
+func (v *pgDSLParser) buildAggregateQuery(chainID *big.Int, expressions []query.Expression, agg Aggregate) (string, *queryArgs, error) {
+	// reset transient properties
+	v.args = newQueryArgs(chainID)
+	v.expression = ""
+	v.err = nil
+
+	where, err := v.whereClause(expressions, query.LimitAndSort{})
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns, conditions, err := v.groupByColumns(agg.GroupBy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns = append(columns, "COUNT(*) AS count")
+
+	switch agg.Func {
+	case AggregateCount:
+	case AggregateMinWord, AggregateMaxWord:
+		if agg.WordIndex < 0 {
+			return "", nil, fmt.Errorf("invalid word index: %d", agg.WordIndex)
+		}
+
+		fn := "min"
+		if agg.Func == AggregateMaxWord {
+			fn = "max"
+		}
+
+		// bytea has no min/max aggregates, but fixed length lowercase hex sorts the same as the uint256 it encodes
+		word := fmt.Sprintf("substring(data from 32*%d+1 for 32)", agg.WordIndex)
+		columns = append(columns, fmt.Sprintf(`decode(%s(encode(%s, 'hex') COLLATE "C"), 'hex') AS value`, fn, word))
+		conditions = append(conditions, fmt.Sprintf("octet_length(data) >= 32*%d", agg.WordIndex+1))
+	default:
+		return "", nil, fmt.Errorf("unexpected aggregate function: %d", agg.Func)
+	}
+
+	for _, condition := range conditions {
+		where = fmt.Sprintf("%s AND %s", where, condition)
+	}
+
+	clauses := []string{fmt.Sprintf("SELECT %s FROM evm.logs", strings.Join(columns, ", ")), where}
+
+	if len(agg.GroupBy) > 0 {
+		positions := make([]string, len(agg.GroupBy))
+		for idx := range agg.GroupBy {
+			positions[idx] = strconv.Itoa(idx + 1)
+		}
+
+		clauses = append(clauses,
+			"GROUP BY "+strings.Join(positions, ", "),
+			"ORDER BY "+strings.Join(positions, ", "),
+		)
+	}
+
+	return strings.Join(clauses, " "), v.args, nil
+}
+
+func (v *pgDSLParser) groupByColumns(groupBy []GroupBy) ([]string, []string, error) {
+	var (
+		columns    = make([]string, 0, len(groupBy)+2)
+		conditions []string
+		seen       = make(map[GroupByKind]struct{}, len(groupBy))
+	)
+
+	for _, group := range groupBy {
+		if _, ok := seen[group.Kind]; ok {
+			return nil, nil, fmt.Errorf("duplicate group by: %d", group.Kind)
+		}
+
+		seen[group.Kind] = struct{}{}
+
+		switch group.Kind {
+		case GroupByAddress:
+			columns = append(columns, "address")
+		case GroupByEventSig:
+			columns = append(columns, eventSigFieldName)
+		case GroupByTopic:
+			if !(group.Topic == 1 || group.Topic == 2 || group.Topic == 3) {
+				return nil, nil, fmt.Errorf("invalid index for topic: %d", group.Topic)
+			}
+
+			// Add 1 since postgresql arrays are 1-indexed.
+			column := fmt.Sprintf("topics[%d]", group.Topic+1)
+			columns = append(columns, column+" AS topic")
+			conditions = append(conditions, column+" IS NOT NULL")
+		case GroupByBlockBucket:
+			if group.BucketSize <= 0 {
+				return nil, nil, fmt.Errorf("invalid block bucket size: %d", group.BucketSize)
+			}
+
+			size := v.args.withIndexedField("bucket_size", group.BucketSize)
+			columns = append(columns, fmt.Sprintf("(%s / :%s) * :%s AS block_bucket", blockFieldName, size, size))
+		default:
+			return nil, nil, fmt.Errorf("unexpected group by: %d", group.Kind)
+		}
+	}
+
+	return columns, conditions, nil
+}
+
 func (v *pgDSLParser) whereClause(expressions []query.Expression, limiter query.LimitAndSort) (string, error) {
 	segment := "WHERE evm_chain_id = :evm_chain_id"
 
@@ -486,6 +664,44 @@ func valuesFromCursor(cursor string) (int64, int, []byte, error) {
 	return block, int(logIdx), txHash, nil
 }
 
+// AggregateFunc is the aggregation computed for every group of an aggregate query.
+type AggregateFunc int
+
+const (
+	// AggregateCount only counts the matching logs.
+	AggregateCount AggregateFunc = iota
+	// AggregateMinWord returns the smallest data word at Aggregate.WordIndex, compared as uint256.
+	AggregateMinWord
+	// AggregateMaxWord returns the largest data word at Aggregate.WordIndex, compared as uint256.
+	AggregateMaxWord
+)
+
+// GroupByKind selects the column logs are grouped by in an aggregate query.
+type GroupByKind int
+
+const (
+	GroupByAddress GroupByKind = iota
+	GroupByEventSig
+	// GroupByTopic groups by the topic at GroupBy.Topic; logs without that topic are excluded.
+	GroupByTopic
+	// GroupByBlockBucket groups by block number rounded down to a multiple of GroupBy.BucketSize.
+	GroupByBlockBucket
+)
+
+type GroupBy struct {
+	Kind       GroupByKind
+	Topic      uint64
+	BucketSize int64
+}
+
+// Aggregate describes an aggregate query over the logs matching the query DSL expressions.
+// Without GroupBy a single row aggregating all matching logs is returned.
+type Aggregate struct {
+	Func      AggregateFunc
+	WordIndex int
+	GroupBy   []GroupBy
+}
+
 type addressFilter struct {
 	address common.Address
 }
@@ -520,6 +736,78 @@ func (f *eventSigFilter) Accept(visitor primitives.Visitor) {
 	}
 }
 
+// The tx filters below only match logs stored by filters with EnrichTx, since other logs have no transaction details.
+
+type txFromFilter struct {
+	from common.Address
+}
+
+func NewTxFromFilter(from common.Address) query.Expression {
+	return query.Expression{
+		Primitive: &txFromFilter{from: from},
+	}
+}
+
+func (f *txFromFilter) Accept(visitor primitives.Visitor) {
+	switch v := visitor.(type) {
+	case *pgDSLParser:
+		v.visitTxFromFilter(f)
+	}
+}
+
+type txToFilter struct {
+	to common.Address
+}
+
+func NewTxToFilter(to common.Address) query.Expression {
+	return query.Expression{
+		Primitive: &txToFilter{to: to},
+	}
+}
+
+func (f *txToFilter) Accept(visitor primitives.Visitor) {
+	switch v := visitor.(type) {
+	case *pgDSLParser:
+		v.visitTxToFilter(f)
+	}
+}
+
+type txStatusFilter struct {
+	status uint64
+}
+
+// NewTxStatusFilter matches logs by receipt status, 1 for successful and 0 for reverted transactions.
+func NewTxStatusFilter(status uint64) query.Expression {
+	return query.Expression{
+		Primitive: &txStatusFilter{status: status},
+	}
+}
+
+func (f *txStatusFilter) Accept(visitor primitives.Visitor) {
+	switch v := visitor.(type) {
+	case *pgDSLParser:
+		v.visitTxStatusFilter(f)
+	}
+}
+
+type txGasUsedFilter struct {
+	gasUsed  uint64
+	operator primitives.ComparisonOperator
+}
+
+func NewTxGasUsedFilter(gasUsed uint64, operator primitives.ComparisonOperator) query.Expression {
+	return query.Expression{
+		Primitive: &txGasUsedFilter{gasUsed: gasUsed, operator: operator},
+	}
+}
+
+func (f *txGasUsedFilter) Accept(visitor primitives.Visitor) {
+	switch v := visitor.(type) {
+	case *pgDSLParser:
+		v.visitTxGasUsedFilter(f)
+	}
+}
+
 type HashedValueComparator struct {
 	Values   []common.Hash
 	Operator primitives.ComparisonOperator
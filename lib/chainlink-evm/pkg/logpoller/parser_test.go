@@ -385,3 +385,128 @@ func TestDSLParser(t *testing.T) {
 		require.Len(t, values["word_value_1"], 2)
 	})
 }
+
+func TestDSLParser_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("count without group by", func(t *testing.T) {
+		t.Parallel()
+
+		parser := &pgDSLParser{}
+		expressions := []query.Expression{NewEventSigFilter(common.HexToHash("0x21"))}
+
+		result, args, err := parser.buildAggregateQuery(big.NewInt(1), expressions, Aggregate{Func: AggregateCount})
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT COUNT(*) AS count FROM evm.logs WHERE evm_chain_id = :evm_chain_id AND event_sig = :event_sig_0", result)
+
+		assertArgs(t, args, 2)
+	})
+
+	t.Run("count per finalized block bucket and topic", func(t *testing.T) {
+		t.Parallel()
+
+		parser := &pgDSLParser{}
+		expressions := []query.Expression{
+			NewAddressFilter(common.HexToAddress("0x42")),
+			query.Confidence(primitives.Finalized),
+		}
+		agg := Aggregate{
+			Func: AggregateCount,
+			GroupBy: []GroupBy{
+				{Kind: GroupByBlockBucket, BucketSize: 100},
+				{Kind: GroupByTopic, Topic: 1},
+			},
+		}
+
+		result, args, err := parser.buildAggregateQuery(big.NewInt(1), expressions, agg)
+		expected := "SELECT (block_number / :bucket_size_0) * :bucket_size_0 AS block_bucket, topics[2] AS topic, COUNT(*) AS count FROM evm.logs " +
+			"WHERE evm_chain_id = :evm_chain_id AND (address = :address_0 " +
+			"AND block_number <= (SELECT finalized_block_number FROM evm.log_poller_blocks WHERE evm_chain_id = :evm_chain_id ORDER BY block_number DESC LIMIT 1)) " +
+			"AND topics[2] IS NOT NULL GROUP BY 1, 2 ORDER BY 1, 2"
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		assertArgs(t, args, 3)
+	})
+
+	t.Run("max word per address", func(t *testing.T) {
+		t.Parallel()
+
+		parser := &pgDSLParser{}
+		agg := Aggregate{
+			Func:      AggregateMaxWord,
+			WordIndex: 2,
+			GroupBy:   []GroupBy{{Kind: GroupByAddress}, {Kind: GroupByEventSig}},
+		}
+
+		result, args, err := parser.buildAggregateQuery(big.NewInt(1), []query.Expression{NewConfirmationsFilter(5)}, agg)
+		expected := "SELECT address, event_sig, COUNT(*) AS count, " +
+			`decode(max(encode(substring(data from 32*2+1 for 32), 'hex') COLLATE "C"), 'hex') AS value FROM evm.logs ` +
+			"WHERE evm_chain_id = :evm_chain_id " +
+			"AND block_number <= (SELECT greatest(block_number - :confs_0, 0) FROM evm.log_poller_blocks WHERE evm_chain_id = :evm_chain_id ORDER BY block_number DESC LIMIT 1) " +
+			"AND octet_length(data) >= 32*3 GROUP BY 1, 2 ORDER BY 1, 2"
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		assertArgs(t, args, 2)
+	})
+
+	t.Run("invalid aggregates", func(t *testing.T) {
+		t.Parallel()
+
+		for _, agg := range []Aggregate{
+			{Func: AggregateFunc(42)},
+			{Func: AggregateMinWord, WordIndex: -1},
+			{GroupBy: []GroupBy{{Kind: GroupByTopic, Topic: 4}}},
+			{GroupBy: []GroupBy{{Kind: GroupByBlockBucket}}},
+			{GroupBy: []GroupBy{{Kind: GroupByAddress}, {Kind: GroupByAddress}}},
+		} {
+			_, _, err := (&pgDSLParser{}).buildAggregateQuery(big.NewInt(1), nil, agg)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestDSLParser_TxFilters(t *testing.T) {
+	t.Parallel()
+
+	parser := &pgDSLParser{}
+	expressions := []query.Expression{
+		NewTxFromFilter(common.HexToAddress("0x42")),
+		NewTxToFilter(common.HexToAddress("0x43")),
+		NewTxStatusFilter(1),
+		NewTxGasUsedFilter(100000, primitives.Gt),
+	}
+
+	result, args, err := parser.buildQuery(big.NewInt(1), expressions, query.LimitAndSort{})
+	expected := logsQuery(
+		" WHERE evm_chain_id = :evm_chain_id " +
+			"AND (tx_from = :tx_from_0 AND tx_to = :tx_to_0 AND tx_status = :tx_status_0 AND tx_gas_used > :tx_gas_used_0) " +
+			"ORDER BY " + defaultSort)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	assertArgs(t, args, 5)
+}
+
+func TestDSLParser_Keyset(t *testing.T) {
+	t.Parallel()
+
+	parser := &pgDSLParser{}
+	expressions := []query.Expression{NewAddressFilter(common.HexToAddress("0x42"))}
+
+	result, args, err := parser.buildKeysetQuery(big.NewInt(1), expressions, nil, 100)
+	require.NoError(t, err)
+	assert.Equal(t, logsQuery(" WHERE evm_chain_id = :evm_chain_id AND address = :address_0 ORDER BY block_number ASC, log_index ASC LIMIT 100"), result)
+	assertArgs(t, args, 2)
+
+	result, args, err = parser.buildKeysetQuery(big.NewInt(1), expressions, &LogPosition{BlockNumber: 10, LogIndex: 3}, 100)
+	require.NoError(t, err)
+	assert.Equal(t, logsQuery(" WHERE evm_chain_id = :evm_chain_id AND address = :address_0 "+
+		"AND (block_number, log_index) > (:after_block_number, :after_log_index) ORDER BY block_number ASC, log_index ASC LIMIT 100"), result)
+	assertArgs(t, args, 4)
+}
@@ -2,6 +2,7 @@ package testutils
 
 import (
 	"fmt"
+	"io"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -179,8 +180,12 @@ func (ts *testWSServer) handleNewMsg(chainID *big.Int, conn *websocket.Conn, cal
 }
 
 func (ts *testWSServer) handleRequest(chainID *big.Int, callback JSONRPCHandler, req gjson.Result) (response, asyncResponse string, err error) {
+	return handleJSONRPCRequest(ts.t, chainID, callback, req)
+}
+
+func handleJSONRPCRequest(t testing.TB, chainID *big.Int, callback JSONRPCHandler, req gjson.Result) (response, asyncResponse string, err error) {
 	if e := req.Get("error"); e.Exists() {
-		ts.t.Logf("Received jsonrpc error: %v", e)
+		t.Logf("Received jsonrpc error: %v", e)
 		return
 	}
 
@@ -223,6 +228,50 @@ func (ts *testWSServer) writeMsg(conn *websocket.Conn, msg string) error {
 	return nil
 }
 
+// NewHTTPServer starts an HTTP server which invokes callback for each JSON-RPC request received, including the
+// elements of batch requests. If chainID is set, then eth_chainId calls will be automatically handled.
+// Subscription notifications are not supported over HTTP, so JSONRPCResponse.Notify is ignored.
+func NewHTTPServer(t *testing.T, chainID *big.Int, callback JSONRPCHandler) *httptest.Server {
+	if callback == nil {
+		callback = func(method string, params gjson.Result) (resp JSONRPCResponse) { return }
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err, "Failed to read HTTP request") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		t.Log("Received HTTP request", string(data))
+
+		var response string
+		req := gjson.ParseBytes(data)
+		if req.IsArray() {
+			var responses []string
+			for _, reqElem := range req.Array() {
+				var resp string
+				resp, _, err = handleJSONRPCRequest(t, chainID, callback, reqElem)
+				if err != nil {
+					break
+				}
+				responses = append(responses, resp)
+			}
+			response = fmt.Sprintf("[%s]", strings.Join(responses, ","))
+		} else {
+			response, _, err = handleJSONRPCRequest(t, chainID, callback, req)
+		}
+		if err != nil {
+			t.Logf("Failed to handle HTTP request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err, "Failed to write HTTP response")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
 type RawSub[T any] struct {
 	ch  chan<- T
 	err <-chan error
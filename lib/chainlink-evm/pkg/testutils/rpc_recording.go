@@ -0,0 +1,461 @@
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	RPCTransportHTTP = "http"
+	RPCTransportWS   = "ws"
+)
+
+// RPCInteraction is a single recorded JSON-RPC exchange. Request and Response hold the raw messages, which are
+// arrays for batch calls.
+type RPCInteraction struct {
+	Transport string          `json:"transport"`
+	Request   json.RawMessage `json:"request"`
+	Response  json.RawMessage `json:"response,omitempty"`
+	// Notifications holds the results of the eth_subscription messages received for the subscription created by Request.
+	Notifications []json.RawMessage `json:"notifications,omitempty"`
+}
+
+// RPCRecording is a session of JSON-RPC exchanges with a node, in the order the requests were sent.
+type RPCRecording struct {
+	Interactions []RPCInteraction `json:"interactions"`
+}
+
+// LoadRPCRecording reads a recording written by RPCRecording.WriteFile.
+func LoadRPCRecording(path string) (RPCRecording, error) {
+	var rec RPCRecording
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return rec, err
+	}
+	if err = json.Unmarshal(b, &rec); err != nil {
+		return rec, fmt.Errorf("failed to parse RPC recording %s: %w", path, err)
+	}
+	return rec, nil
+}
+
+// MustLoadRPCRecording is like LoadRPCRecording, but fails the test on error.
+func MustLoadRPCRecording(t testing.TB, path string) RPCRecording {
+	rec, err := LoadRPCRecording(path)
+	require.NoError(t, err)
+	return rec
+}
+
+// WriteFile writes the recording as indented JSON, creating the parent directories if necessary.
+func (r RPCRecording) WriteFile(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
+
+// RPCRecorder is a proxy in front of a real node, which records every request and response passing through it. Point
+// an RPC client at WSURL and HTTPURL instead of the node to record its session, e.g.:
+//
+//	rec := testutils.NewRPCRecorder(t, "testdata/recordings/my_test.json", nodeWSURL, nodeHTTPURL)
+//	rpc := client.NewRPCClient(cfg, lggr, rec.WSURL(), rec.HTTPURL(), "recorded", 1, chainID, multinode.Primary, ...)
+//
+// and replay it offline with NewRPCReplayServer.
+type RPCRecorder struct {
+	t                        *testing.T
+	wsUpstream, httpUpstream *url.URL
+	ws, http                 *httptest.Server
+
+	mu           sync.Mutex
+	interactions []*RPCInteraction
+	conns        []*websocket.Conn
+	closed       bool
+	wg           sync.WaitGroup
+}
+
+// NewRPCRecorder starts proxies for the given node URLs, either of which may be nil. If path is set, the recording is
+// written to it when the test finishes, unless it failed.
+func NewRPCRecorder(t *testing.T, path string, wsURL, httpURL *url.URL) *RPCRecorder {
+	r := &RPCRecorder{t: t, wsUpstream: wsURL, httpUpstream: httpURL}
+	if wsURL != nil {
+		r.ws = httptest.NewServer(http.HandlerFunc(r.proxyWS))
+	}
+	if httpURL != nil {
+		r.http = httptest.NewServer(http.HandlerFunc(r.proxyHTTP))
+	}
+	t.Cleanup(func() {
+		r.Close()
+		if path != "" && !t.Failed() {
+			require.NoError(t, r.Recording().WriteFile(path))
+		}
+	})
+	return r
+}
+
+// WSURL returns the ws:// url of the websocket proxy, or nil if there is no websocket node.
+func (r *RPCRecorder) WSURL() *url.URL {
+	if r.ws == nil {
+		return nil
+	}
+	return WSServerURL(r.t, r.ws)
+}
+
+// HTTPURL returns the url of the HTTP proxy, or nil if there is no HTTP node.
+func (r *RPCRecorder) HTTPURL() *url.URL {
+	if r.http == nil {
+		return nil
+	}
+	u, err := url.Parse(r.http.URL)
+	require.NoError(r.t, err, "Failed to parse url")
+	return u
+}
+
+// Recording returns a copy of the exchanges recorded so far.
+func (r *RPCRecorder) Recording() RPCRecording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := RPCRecording{Interactions: make([]RPCInteraction, 0, len(r.interactions))}
+	for _, i := range r.interactions {
+		cpy := *i
+		cpy.Notifications = append([]json.RawMessage(nil), i.Notifications...)
+		rec.Interactions = append(rec.Interactions, cpy)
+	}
+	return rec
+}
+
+// Close stops the proxies and closes all websocket connections.
+func (r *RPCRecorder) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	for _, conn := range r.conns {
+		conn.Close()
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	if r.ws != nil {
+		r.ws.Close()
+	}
+	if r.http != nil {
+		r.http.Close()
+	}
+}
+
+func compactJSON(b []byte) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func (r *RPCRecorder) add(i *RPCInteraction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, i)
+}
+
+func (r *RPCRecorder) proxyHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	upstreamReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, r.httpUpstream.String(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(upstreamReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if reqJSON, respJSON := compactJSON(body), compactJSON(respBody); reqJSON != nil && respJSON != nil {
+		r.add(&RPCInteraction{Transport: RPCTransportHTTP, Request: reqJSON, Response: respJSON})
+	} else {
+		r.t.Logf("Not recording HTTP exchange with invalid JSON (status %d): %s", resp.StatusCode, respBody)
+	}
+
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(respBody)
+}
+
+// wsSession tracks the requests of a single websocket connection which are waiting for a response, and the
+// subscriptions created on it. It is protected by the RPCRecorder's mutex.
+type wsSession struct {
+	pending       map[string]*RPCInteraction // request id => interaction
+	pendingMethod map[string]string          // request id => method
+	subs          map[string]*RPCInteraction // subscription id => eth_subscribe interaction
+}
+
+func (r *RPCRecorder) proxyWS(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		http.Error(w, "recorder closed", http.StatusServiceUnavailable)
+		return
+	}
+	r.wg.Add(1)
+	r.mu.Unlock()
+	defer r.wg.Done()
+
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		r.t.Logf("Failed to upgrade WS connection: %v", err)
+		return
+	}
+	upstream, _, err := websocket.DefaultDialer.DialContext(req.Context(), r.wsUpstream.String(), nil)
+	if err != nil {
+		r.t.Logf("Failed to dial upstream WS: %v", err)
+		conn.Close()
+		return
+	}
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		conn.Close()
+		upstream.Close()
+		return
+	}
+	r.conns = append(r.conns, conn, upstream)
+	r.mu.Unlock()
+
+	s := &wsSession{
+		pending:       make(map[string]*RPCInteraction),
+		pendingMethod: make(map[string]string),
+		subs:          make(map[string]*RPCInteraction),
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.forwardWS(upstream, conn, func(msg []byte) { r.onWSResponse(s, msg) })
+	}()
+	r.forwardWS(conn, upstream, func(msg []byte) { r.onWSRequest(s, msg) })
+	conn.Close()
+	upstream.Close()
+	<-done
+}
+
+// forwardWS copies messages from one connection to the other, until either is closed. observe is called with every
+// message before it is forwarded.
+func (r *RPCRecorder) forwardWS(from, to *websocket.Conn, observe func([]byte)) {
+	for {
+		msgType, msg, err := from.ReadMessage()
+		if err != nil {
+			return
+		}
+		observe(msg)
+		if err = to.WriteMessage(msgType, msg); err != nil {
+			return
+		}
+	}
+}
+
+func (r *RPCRecorder) onWSRequest(s *wsSession, msg []byte) {
+	reqJSON := compactJSON(msg)
+	if reqJSON == nil {
+		r.t.Logf("Not recording WS request with invalid JSON: %s", msg)
+		return
+	}
+	i := &RPCInteraction{Transport: RPCTransportWS, Request: reqJSON}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, i)
+	req := gjson.ParseBytes(msg)
+	if req.IsArray() {
+		for _, elem := range req.Array() {
+			s.pending[elem.Get("id").Raw] = i
+		}
+		return
+	}
+	id := req.Get("id").Raw
+	s.pending[id] = i
+	s.pendingMethod[id] = req.Get("method").String()
+}
+
+func (r *RPCRecorder) onWSResponse(s *wsSession, msg []byte) {
+	respJSON := compactJSON(msg)
+	if respJSON == nil {
+		r.t.Logf("Not recording WS response with invalid JSON: %s", msg)
+		return
+	}
+	resp := gjson.ParseBytes(msg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if resp.IsArray() {
+		elems := resp.Array()
+		if len(elems) == 0 {
+			return
+		}
+		i, ok := s.pending[elems[0].Get("id").Raw]
+		if !ok {
+			return
+		}
+		i.Response = respJSON
+		for _, elem := range elems {
+			delete(s.pending, elem.Get("id").Raw)
+		}
+		return
+	}
+
+	if resp.Get("method").String() == "eth_subscription" {
+		if i, ok := s.subs[resp.Get("params.subscription").String()]; ok {
+			i.Notifications = append(i.Notifications, json.RawMessage(resp.Get("params.result").Raw))
+		}
+		return
+	}
+
+	id := resp.Get("id").Raw
+	i, ok := s.pending[id]
+	if !ok {
+		return
+	}
+	i.Response = respJSON
+	if s.pendingMethod[id] == "eth_subscribe" && resp.Get("result").Type == gjson.String {
+		s.subs[resp.Get("result").String()] = i
+	}
+	delete(s.pending, id)
+	delete(s.pendingMethod, id)
+}
+
+// RPCReplayServer serves the responses of an RPCRecording over websocket and HTTP, using the servers of NewWSServer
+// and NewHTTPServer. Requests are matched by method and params, regardless of the transport and of whether they were
+// batched. Repeated requests get the recorded responses in order, repeating the last one once they are exhausted.
+// Requests without a recorded response get a JSON-RPC error.
+//
+// Subscriptions get their id replaced by "0x00" and only the first recorded notification is sent, since that is all
+// the websocket server supports.
+type RPCReplayServer struct {
+	t    *testing.T
+	ws   *testWSServer
+	http *httptest.Server
+
+	mu        sync.Mutex
+	responses map[string][]JSONRPCResponse // method and params => responses in recorded order
+	served    map[string]int
+}
+
+// NewRPCReplayServer starts websocket and HTTP servers replaying rec.
+func NewRPCReplayServer(t *testing.T, rec RPCRecording) *RPCReplayServer {
+	s := &RPCReplayServer{
+		t:         t,
+		responses: make(map[string][]JSONRPCResponse),
+		served:    make(map[string]int),
+	}
+	for n, i := range rec.Interactions {
+		req, resp := gjson.ParseBytes(i.Request), gjson.ParseBytes(i.Response)
+		if !req.IsArray() {
+			s.addResponse(req, resp, i.Notifications)
+			continue
+		}
+		respByID := make(map[string]gjson.Result)
+		for _, elem := range resp.Array() {
+			respByID[elem.Get("id").Raw] = elem
+		}
+		for _, elem := range req.Array() {
+			if elemResp, ok := respByID[elem.Get("id").Raw]; ok {
+				s.addResponse(elem, elemResp, nil)
+			} else {
+				t.Logf("Recorded batch request %d has no response for %s", n, elem.Raw)
+			}
+		}
+	}
+	s.ws = NewWSServer(t, nil, s.handle)
+	s.http = NewHTTPServer(t, nil, s.handle)
+	return s
+}
+
+func requestKey(method string, params gjson.Result) string {
+	p := []byte("[]")
+	if params.Exists() {
+		if c := compactJSON([]byte(params.Raw)); c != nil {
+			p = c
+		}
+	}
+	return method + string(p)
+}
+
+func (s *RPCReplayServer) addResponse(req, resp gjson.Result, notifications []json.RawMessage) {
+	if !resp.Exists() {
+		return
+	}
+	method := req.Get("method").String()
+	var r JSONRPCResponse
+	if e := resp.Get("error"); e.Exists() {
+		r.Error.Code = int(e.Get("code").Int())
+		r.Error.Message = e.Get("message").String()
+	} else {
+		r.Result = resp.Get("result").Raw
+	}
+	if method == "eth_subscribe" && r.Error.Message == "" {
+		r.Result = `"0x00"`
+		if len(notifications) > 0 {
+			r.Notify = string(notifications[0])
+		}
+	}
+	key := requestKey(method, req.Get("params"))
+	s.responses[key] = append(s.responses[key], r)
+}
+
+func (s *RPCReplayServer) handle(method string, params gjson.Result) JSONRPCResponse {
+	key := requestKey(method, params)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	responses := s.responses[key]
+	if len(responses) == 0 {
+		s.t.Logf("No recorded response for %s", key)
+		var resp JSONRPCResponse
+		resp.Error.Code = -32601
+		resp.Error.Message = "no recorded response for " + method
+		return resp
+	}
+	n := min(s.served[key], len(responses)-1)
+	s.served[key]++
+	return responses[n]
+}
+
+// WSURL returns the ws:// url of the replay server.
+func (s *RPCReplayServer) WSURL() *url.URL {
+	return s.ws.WSURL()
+}
+
+// HTTPURL returns the url of the HTTP replay server.
+func (s *RPCReplayServer) HTTPURL() *url.URL {
+	u, err := url.Parse(s.http.URL)
+	require.NoError(s.t, err, "Failed to parse url")
+	return u
+}
@@ -0,0 +1,103 @@
+package testutils
+
+import (
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestRPCRecorder_RecordAndReplay(t *testing.T) {
+	addr1, addr2 := NewAddress().Hex(), NewAddress().Hex()
+	node := func(method string, params gjson.Result) (resp JSONRPCResponse) {
+		switch method {
+		case "eth_blockNumber":
+			resp.Result = `"0x10"`
+		case "eth_getBalance":
+			resp.Result = `"0x2"`
+			if params.Get("0").String() == addr1 {
+				resp.Result = `"0x1"`
+			}
+		case "eth_subscribe":
+			resp.Result = `"0x00"`
+			resp.Notify = `{"number":"0x11"}`
+		}
+		return
+	}
+	chainID := big.NewInt(1337)
+	wsNode := NewWSServer(t, chainID, node)
+	httpNode := NewHTTPServer(t, chainID, node)
+	httpNodeURL := WSServerURL(t, httpNode)
+	httpNodeURL.Scheme = "http"
+
+	exercise := func(t *testing.T, wsURL, httpURL string) {
+		ctx := Context(t)
+		ws, err := rpc.DialWebsocket(ctx, wsURL, "")
+		require.NoError(t, err)
+		defer ws.Close()
+		http, err := rpc.DialHTTP(httpURL)
+		require.NoError(t, err)
+		defer http.Close()
+
+		var blockNumber hexutil.Uint64
+		require.NoError(t, ws.CallContext(ctx, &blockNumber, "eth_blockNumber"))
+		assert.Equal(t, hexutil.Uint64(0x10), blockNumber)
+
+		var balance1, balance2 hexutil.Big
+		batch := []rpc.BatchElem{
+			{Method: "eth_getBalance", Args: []any{addr1, "latest"}, Result: &balance1},
+			{Method: "eth_getBalance", Args: []any{addr2, "latest"}, Result: &balance2},
+		}
+		require.NoError(t, ws.BatchCallContext(ctx, batch))
+		require.NoError(t, batch[0].Error)
+		require.NoError(t, batch[1].Error)
+		assert.Equal(t, int64(1), balance1.ToInt().Int64())
+
+		var httpBalance hexutil.Big
+		require.NoError(t, http.CallContext(ctx, &httpBalance, "eth_getBalance", addr2, "latest"))
+		assert.Equal(t, balance2, httpBalance)
+
+		heads := make(chan json.RawMessage, 1)
+		sub, err := ws.EthSubscribe(ctx, heads, "newHeads")
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		select {
+		case head := <-heads:
+			assert.JSONEq(t, `{"number":"0x11"}`, string(head))
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for head")
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "recordings", "session.json")
+	t.Run("record", func(t *testing.T) {
+		rec := NewRPCRecorder(t, path, wsNode.WSURL(), httpNodeURL)
+		exercise(t, rec.WSURL().String(), rec.HTTPURL().String())
+
+		recording := rec.Recording()
+		// the eth_unsubscribe sent when the subscription is closed is recorded as well
+		require.Len(t, recording.Interactions, 5)
+		assert.Equal(t, RPCTransportWS, recording.Interactions[0].Transport)
+		assert.True(t, gjson.ParseBytes(recording.Interactions[1].Response).IsArray())
+		assert.Equal(t, RPCTransportHTTP, recording.Interactions[2].Transport)
+		require.Len(t, recording.Interactions[3].Notifications, 1)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		rec := MustLoadRPCRecording(t, path)
+		server := NewRPCReplayServer(t, rec)
+		exercise(t, server.WSURL().String(), server.HTTPURL().String())
+
+		ws, err := rpc.DialHTTP(server.HTTPURL().String())
+		require.NoError(t, err)
+		defer ws.Close()
+		var res string
+		require.ErrorContains(t, ws.CallContext(Context(t), &res, "eth_gasPrice"), "no recorded response for eth_gasPrice")
+	})
+}
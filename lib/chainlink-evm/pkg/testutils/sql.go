@@ -17,8 +17,12 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil/sqltest"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller/migrations"
 )
 
+// NewSqlxDB returns a test database backed by a transaction, with the log poller migrations applied on top of the
+// node's schema.
 func NewSqlxDB(t testing.TB) *sqlx.DB {
 	SkipShortDB(t)
 	dbURL := os.Getenv("CL_DATABASE_URL")
@@ -26,7 +30,9 @@ func NewSqlxDB(t testing.TB) *sqlx.DB {
 		t.Errorf("you must provide a CL_DATABASE_URL environment variable")
 		return nil
 	}
-	return sqltest.NewDB(t, dbURL)
+	db := sqltest.NewDB(t, dbURL)
+	require.NoError(t, migrations.Up(tests.Context(t), db.DB))
+	return db
 }
 
 // SkipShortDB skips tb during -short runs, and notes the DB dependency.
@@ -61,6 +67,7 @@ func NewIndependentSqlxDB(t testing.TB) *sqlx.DB {
 	}.New(ctx, newDBURL.String(), pg.DriverPostgres)
 	require.NoError(t, err)
 	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+	require.NoError(t, migrations.Up(ctx, db.DB))
 
 	return db
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/mailbox"
 
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
 	"github.com/smartcontractkit/chainlink-evm/pkg/types"
 	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
 	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
@@ -492,7 +493,9 @@ func (f *evmFinalizer) validateReceipt(ctx context.Context, receipt *types.Recei
 	}
 
 	if receipt.GetStatus() == 0 {
-		if receipt.GetRevertReason() != nil {
+		if revert := client.RevertErrors.Decode(receipt.RevertReason); revert != nil {
+			l.Warnw("transaction reverted on-chain", "hash", receipt.GetTxHash(), "revertReason", revert.String())
+		} else if receipt.GetRevertReason() != nil {
 			l.Warnw("transaction reverted on-chain", "hash", receipt.GetTxHash(), "revertReason", *receipt.GetRevertReason())
 		} else {
 			rpcError, errExtract := f.client.CallContract(ctx, attempt, receipt.GetBlockNumber())
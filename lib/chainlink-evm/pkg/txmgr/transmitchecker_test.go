@@ -159,6 +159,27 @@ func TestTransmitCheckers(t *testing.T) {
 			require.EqualError(t, err, expErrMsg)
 		})
 
+		t.Run("revert with reason", func(t *testing.T) {
+			// ABI encoded Error("boom")
+			data := "0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"626f6f6d00000000000000000000000000000000000000000000000000000000"
+			jerr := evmclient.JsonError{
+				Code:    3,
+				Message: "execution reverted: boom",
+				Data:    data,
+			}
+			client.On("CallContext", mock.Anything,
+				mock.AnythingOfType("*hexutil.Bytes"), "eth_call",
+				mock.MatchedBy(func(callarg map[string]interface{}) bool {
+					return fmt.Sprintf("%s", callarg["value"]) == "0x282" // 642
+				}), "latest").Return(&jerr).Once()
+
+			err := checker.Check(ctx, log, tx, attempt)
+			require.ErrorContains(t, err, `Revert = 'Error("boom")'`)
+		})
+
 		t.Run("non revert error", func(t *testing.T) {
 			client.On("CallContext", mock.Anything,
 				mock.AnythingOfType("*hexutil.Bytes"), "eth_call",
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"regexp"
@@ -48,7 +49,12 @@ type Head struct {
 	StateRoot        common.Hash
 	Difficulty       *big.Int
 	TotalDifficulty  *big.Int
+	LogsBloom        *types.Bloom // nil if the RPC didn't return a bloom
 	IsFinalized      atomic.Bool
+
+	// headerFields holds the remaining header fields the RPC returned, still encoded, so that Hash can be verified
+	// without decoding them for every head. It is nil unless the head was decoded from JSON.
+	headerFields *rawHeaderFields
 }
 
 var _ chains.Head[common.Hash] = &Head{}
@@ -72,6 +78,51 @@ func (h *Head) SetFromHeader(header *types.Header) {
 	//nolint:gosec // G115
 	h.Timestamp = time.Unix(int64(header.Time), 0)
 	h.Difficulty = header.Difficulty
+	bloom := header.Bloom
+	h.LogsBloom = &bloom
+}
+
+var (
+	// ErrIncompleteHeader is returned by VerifyHash if the RPC omitted header fields needed to compute the block hash.
+	ErrIncompleteHeader = errors.New("header is missing fields required to compute its hash")
+	// ErrHeaderHashMismatch is returned by VerifyHash if the header fields don't hash to the head's Hash.
+	ErrHeaderHashMismatch = errors.New("header does not hash to the claimed block hash")
+)
+
+// VerifyHash recomputes the block hash from the RLP encoding of the header fields returned by the RPC and checks
+// that it matches Hash. Only heads decoded from JSON can be verified.
+func (h *Head) VerifyHash() error {
+	header := h.headerFields.header(h)
+	if header == nil {
+		return ErrIncompleteHeader
+	}
+	if computed := header.Hash(); computed != h.Hash {
+		return fmt.Errorf("%w: computed %s, claimed %s", ErrHeaderHashMismatch, computed, h.Hash)
+	}
+	return nil
+}
+
+// Copy returns a copy of the head as the RPC returned it, without the parent chain and finality flag that the head
+// tracker sets.
+func (h *Head) Copy() *Head {
+	return &Head{
+		ID:               h.ID,
+		Hash:             h.Hash,
+		Number:           h.Number,
+		L1BlockNumber:    h.L1BlockNumber,
+		ParentHash:       h.ParentHash,
+		EVMChainID:       h.EVMChainID,
+		Timestamp:        h.Timestamp,
+		CreatedAt:        h.CreatedAt,
+		BaseFeePerGas:    h.BaseFeePerGas,
+		ReceiptsRoot:     h.ReceiptsRoot,
+		TransactionsRoot: h.TransactionsRoot,
+		StateRoot:        h.StateRoot,
+		Difficulty:       h.Difficulty,
+		TotalDifficulty:  h.TotalDifficulty,
+		LogsBloom:        h.LogsBloom,
+		headerFields:     h.headerFields,
+	}
 }
 
 func (h *Head) BlockNumber() int64 {
@@ -290,17 +341,20 @@ func (h Hash) Bytes() []byte { return h[:] }
 
 func (h *Head) UnmarshalJSON(bs []byte) error {
 	type head struct {
-		Hash             Hash           `json:"hash"`
-		Number           *hexutil.Big   `json:"number"`
-		ParentHash       Hash           `json:"parentHash"`
-		Timestamp        hexutil.Uint64 `json:"timestamp"`
-		L1BlockNumber    *hexutil.Big   `json:"l1BlockNumber"`
-		BaseFeePerGas    *hexutil.Big   `json:"baseFeePerGas"`
-		ReceiptsRoot     Hash           `json:"receiptsRoot"`
-		TransactionsRoot Hash           `json:"transactionsRoot"`
-		StateRoot        Hash           `json:"stateRoot"`
-		Difficulty       *hexutil.Big   `json:"difficulty"`
-		TotalDifficulty  *hexutil.Big   `json:"totalDifficulty"`
+		Hash             Hash            `json:"hash"`
+		Number           *hexutil.Big    `json:"number"`
+		ParentHash       Hash            `json:"parentHash"`
+		Timestamp        hexutil.Uint64  `json:"timestamp"`
+		L1BlockNumber    *hexutil.Big    `json:"l1BlockNumber"`
+		BaseFeePerGas    *hexutil.Big    `json:"baseFeePerGas"`
+		ReceiptsRoot     Hash            `json:"receiptsRoot"`
+		TransactionsRoot Hash            `json:"transactionsRoot"`
+		StateRoot        Hash            `json:"stateRoot"`
+		Difficulty       *hexutil.Big    `json:"difficulty"`
+		TotalDifficulty  *hexutil.Big    `json:"totalDifficulty"`
+		LogsBloom        json.RawMessage `json:"logsBloom"`
+
+		rawHeaderFields
 	}
 
 	var jsonHead head
@@ -327,9 +381,95 @@ func (h *Head) UnmarshalJSON(bs []byte) error {
 	h.StateRoot = common.Hash(jsonHead.StateRoot)
 	h.Difficulty = jsonHead.Difficulty.ToInt()
 	h.TotalDifficulty = jsonHead.TotalDifficulty.ToInt()
+	// some RPCs return a truncated bloom, treat it as missing rather than rejecting the head
+	h.LogsBloom = nil
+	if len(jsonHead.LogsBloom) > 0 && string(jsonHead.LogsBloom) != "null" {
+		var bloom types.Bloom
+		if json.Unmarshal(jsonHead.LogsBloom, &bloom) == nil {
+			h.LogsBloom = &bloom
+		}
+	}
+
+	h.headerFields = &jsonHead.rawHeaderFields
 	return nil
 }
 
+// rawHeaderFields holds the header fields which Head only needs to verify its hash. They are only decoded by VerifyHash,
+// and leniently, so that heads from RPCs returning them in unexpected formats can still be used, just not verified.
+type rawHeaderFields struct {
+	UncleHash        json.RawMessage `json:"sha3Uncles"`
+	Miner            json.RawMessage `json:"miner"`
+	GasLimit         json.RawMessage `json:"gasLimit"`
+	GasUsed          json.RawMessage `json:"gasUsed"`
+	ExtraData        json.RawMessage `json:"extraData"`
+	MixHash          json.RawMessage `json:"mixHash"`
+	Nonce            json.RawMessage `json:"nonce"`
+	WithdrawalsRoot  json.RawMessage `json:"withdrawalsRoot"`
+	BlobGasUsed      json.RawMessage `json:"blobGasUsed"`
+	ExcessBlobGas    json.RawMessage `json:"excessBlobGas"`
+	ParentBeaconRoot json.RawMessage `json:"parentBeaconBlockRoot"`
+	RequestsHash     json.RawMessage `json:"requestsHash"`
+}
+
+// header combines f with the fields already decoded into h. It returns nil if f is nil, or if a field required to
+// compute the hash is missing or malformed.
+func (f *rawHeaderFields) header(h *Head) *types.Header {
+	if f == nil || h.LogsBloom == nil || h.Difficulty == nil {
+		return nil
+	}
+	header := &types.Header{
+		ParentHash:  h.ParentHash,
+		Root:        h.StateRoot,
+		TxHash:      h.TransactionsRoot,
+		ReceiptHash: h.ReceiptsRoot,
+		Bloom:       *h.LogsBloom,
+		Difficulty:  h.Difficulty,
+		Number:      big.NewInt(h.Number),
+		Time:        uint64(h.Timestamp.Unix()), //nolint:gosec // G115
+		BaseFee:     h.BaseFeePerGas.ToInt(),
+	}
+	var (
+		gasLimit, gasUsed          hexutil.Uint64
+		extra                      hexutil.Bytes
+		blobGasUsed, excessBlobGas *hexutil.Uint64
+	)
+	isNull := func(raw json.RawMessage) bool { return len(raw) == 0 || string(raw) == "null" }
+	for _, field := range []struct {
+		raw      json.RawMessage
+		dst      any
+		required bool
+	}{
+		{f.UncleHash, &header.UncleHash, true},
+		{f.Miner, &header.Coinbase, true},
+		{f.GasLimit, &gasLimit, true},
+		{f.GasUsed, &gasUsed, true},
+		{f.ExtraData, &extra, true},
+		{f.MixHash, &header.MixDigest, false},
+		{f.Nonce, &header.Nonce, false},
+		{f.WithdrawalsRoot, &header.WithdrawalsHash, false},
+		{f.BlobGasUsed, &blobGasUsed, false},
+		{f.ExcessBlobGas, &excessBlobGas, false},
+		{f.ParentBeaconRoot, &header.ParentBeaconRoot, false},
+		{f.RequestsHash, &header.RequestsHash, false},
+	} {
+		if isNull(field.raw) {
+			if field.required {
+				return nil
+			}
+			continue
+		}
+		if err := json.Unmarshal(field.raw, field.dst); err != nil {
+			return nil
+		}
+	}
+	header.GasLimit = uint64(gasLimit)
+	header.GasUsed = uint64(gasUsed)
+	header.Extra = extra
+	header.BlobGasUsed = (*uint64)(blobGasUsed)
+	header.ExcessBlobGas = (*uint64)(excessBlobGas)
+	return header
+}
+
 func (h *Head) MarshalJSON() ([]byte, error) {
 	type head struct {
 		Hash             *common.Hash    `json:"hash,omitempty"`
@@ -341,6 +481,7 @@ func (h *Head) MarshalJSON() ([]byte, error) {
 		StateRoot        *common.Hash    `json:"stateRoot,omitempty"`
 		Difficulty       *hexutil.Big    `json:"difficulty,omitempty"`
 		TotalDifficulty  *hexutil.Big    `json:"totalDifficulty,omitempty"`
+		LogsBloom        *types.Bloom    `json:"logsBloom,omitempty"`
 	}
 
 	var jsonHead head
@@ -366,6 +507,7 @@ func (h *Head) MarshalJSON() ([]byte, error) {
 	}
 	jsonHead.Difficulty = (*hexutil.Big)(h.Difficulty)
 	jsonHead.TotalDifficulty = (*hexutil.Big)(h.TotalDifficulty)
+	jsonHead.LogsBloom = h.LogsBloom
 	return json.Marshal(jsonHead)
 }
 
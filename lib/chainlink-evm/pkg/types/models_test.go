@@ -297,6 +297,7 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 				ReceiptsRoot:     common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
 				TransactionsRoot: common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
 				StateRoot:        common.HexToHash("0xc7b01007a10da045eacb90385887dd0c38fcb5db7393006bdde24b93873c334b"),
+				LogsBloom:        &gethtypes.Bloom{},
 			},
 		},
 		{"parity",
@@ -309,6 +310,7 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 				ReceiptsRoot:     common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
 				TransactionsRoot: common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
 				StateRoot:        common.HexToHash("0xc7b01007a10da045eacb90385887dd0c38fcb5db7393006bdde24b93873c334b"),
+				LogsBloom:        &gethtypes.Bloom{},
 			},
 		},
 		{"arbitrum",
@@ -322,6 +324,7 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 				ReceiptsRoot:     common.HexToHash("0x2c292672b8fc9d223647a2569e19721f0757c96a1421753a93e141f8e56cf504"),
 				TransactionsRoot: common.HexToHash("0x71448077f5ce420a8e24db62d4d58e8d8e6ad2c7e76318868e089d41f7e0faf3"),
 				StateRoot:        common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
+				LogsBloom:        &gethtypes.Bloom{},
 			},
 		},
 		{"arbitrum_empty_l1BlockNumber",
@@ -335,6 +338,16 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 				ReceiptsRoot:     common.HexToHash("0x2c292672b8fc9d223647a2569e19721f0757c96a1421753a93e141f8e56cf504"),
 				TransactionsRoot: common.HexToHash("0x71448077f5ce420a8e24db62d4d58e8d8e6ad2c7e76318868e089d41f7e0faf3"),
 				StateRoot:        common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
+				LogsBloom:        &gethtypes.Bloom{},
+			},
+		},
+		{"truncated_logsBloom",
+			`{"number":"0x7b","hash":"0xabc123abc123abc123abc123abc123abc123abc123abc123abc123abc123abc1","parentHash":"0x41941023680923e0fe4d74a34bdac8141f2540e3ae90623718e47d66d1ca4a2d","logsBloom":"0x0","timestamp":"0x58318da2"}`,
+			&Head{
+				Hash:       common.HexToHash("0xabc123abc123abc123abc123abc123abc123abc123abc123abc123abc123abc1"),
+				Number:     0x7b,
+				ParentHash: common.HexToHash("0x41941023680923e0fe4d74a34bdac8141f2540e3ae90623718e47d66d1ca4a2d"),
+				Timestamp:  time.Unix(0x58318da2, 0).UTC(),
 			},
 		},
 		{"not found",
@@ -357,6 +370,73 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 			assert.Equal(t, test.expected.ReceiptsRoot, head.ReceiptsRoot)
 			assert.Equal(t, test.expected.TransactionsRoot, head.TransactionsRoot)
 			assert.Equal(t, test.expected.StateRoot, head.StateRoot)
+			assert.Equal(t, test.expected.LogsBloom, head.LogsBloom)
+		})
+	}
+}
+
+func TestHead_VerifyHash(t *testing.T) {
+	t.Parallel()
+
+	baseFee, blobGasUsed, excessBlobGas := big.NewInt(7), uint64(131072), uint64(0)
+	beaconRoot, withdrawalsRoot := utils.NewHash(), utils.NewHash()
+	for name, header := range map[string]*gethtypes.Header{
+		"legacy": {
+			ParentHash: utils.NewHash(),
+			UncleHash:  gethtypes.EmptyUncleHash,
+			Coinbase:   utils.RandomAddress(),
+			Root:       utils.NewHash(),
+			Difficulty: big.NewInt(2),
+			Number:     big.NewInt(100),
+			GasLimit:   30_000_000,
+			GasUsed:    21_000,
+			Time:       1700000000,
+			Extra:      []byte("extra"),
+		},
+		"cancun": {
+			ParentHash:       utils.NewHash(),
+			UncleHash:        gethtypes.EmptyUncleHash,
+			Root:             utils.NewHash(),
+			TxHash:           gethtypes.EmptyTxsHash,
+			ReceiptHash:      gethtypes.EmptyReceiptsHash,
+			Difficulty:       big.NewInt(0),
+			Number:           big.NewInt(20_000_000),
+			GasLimit:         30_000_000,
+			Time:             1700000012,
+			Extra:            []byte{},
+			BaseFee:          baseFee,
+			WithdrawalsHash:  &withdrawalsRoot,
+			BlobGasUsed:      &blobGasUsed,
+			ExcessBlobGas:    &excessBlobGas,
+			ParentBeaconRoot: &beaconRoot,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			bs, err := json.Marshal(header)
+			require.NoError(t, err)
+
+			var head Head
+			require.NoError(t, json.Unmarshal(bs, &head))
+			assert.Equal(t, header.Hash(), head.Hash)
+			require.NoError(t, head.VerifyHash())
+
+			var fromHeader Head
+			fromHeader.SetFromHeader(header)
+			require.ErrorIs(t, fromHeader.VerifyHash(), ErrIncompleteHeader)
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(bs, &fields))
+			fields["gasUsed"] = "0x1"
+			bs, err = json.Marshal(fields)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(bs, &head))
+			require.ErrorIs(t, head.VerifyHash(), ErrHeaderHashMismatch)
+
+			delete(fields, "miner")
+			bs, err = json.Marshal(fields)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(bs, &head))
+			require.ErrorIs(t, head.VerifyHash(), ErrIncompleteHeader)
 		})
 	}
 }